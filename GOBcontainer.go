@@ -0,0 +1,332 @@
+package signals
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sync"
+)
+
+/*
+GOB container.
+
+a header, then the Signal, which is encoded by type name and parameter values, (the same as the text form, see ParseSignal) rather than by gob's registered Go type names, so isn't broken by changes to the Go types, and a type that is renamed can still be read, see RegisterGOBAlias.
+
+	"signals GOB\n"
+	GOBHeader, gob encoded.
+	Signal, gob encoded, GOBHeader.Length bytes.
+*/
+
+// the GOB container format version written, and the highest that can be read.
+const GOBContainerVersion = 1
+
+// version of this package, written into GOB containers.
+const PackageVersion = "1.1"
+
+// identifies a GOB container, its first bytes.
+const gobContainerMagic = "signals GOB\n"
+
+// GOBHeader is the information stored with a Signal in a GOB container.
+// SampleRate and SampleBytes are hints for rendering, zero for no preference.
+type GOBHeader struct {
+	Version        int    // container format version, set when written.
+	PackageVersion string // set when written.
+	SampleRate     uint32
+	SampleBytes    uint8
+	Author         string
+	Title          string
+	Length         uint64 // of the encoded Signal, set when written.
+	Checksum       uint32 // CRC-32 (IEEE) of the encoded Signal, set when written.
+	FloatXY        bool   // x's and y's held as floats, seconds and fractions of one, by the float64 representation, set when written.
+}
+
+// a Signal as its type name and parameter values, in parameter order, collected by Go type.
+type gobSignal struct {
+	Type    string
+	Signals []gobSignal
+	Ints    []int64   // x's, (nanoseconds), y's, (fractions of math.MaxInt64), and int's
+	Floats  []float64 // float32's, float64's, and x's and y's when written by the float64 representation.
+	Bools   []bool
+	Strings []string
+	Bytes   [][]byte // []byte's and big.Int's
+}
+
+var gobAliases = struct {
+	sync.RWMutex
+	names map[string]string
+}{names: map[string]string{}}
+
+// RegisterGOBAlias makes GOB containers with a Signal type called oldName read as the type called name.
+// the type must have the same parameters as the old one had.
+func RegisterGOBAlias(oldName, name string) error {
+	if _, ok := signalTypes[name]; !ok {
+		return errors.New(fmt.Sprintf("unknown Signal type %q.", name))
+	}
+	if _, ok := signalTypes[oldName]; ok {
+		return errors.New(fmt.Sprintf("%q is a current Signal type.", oldName))
+	}
+	gobAliases.Lock()
+	gobAliases.names[oldName] = name
+	gobAliases.Unlock()
+	return nil
+}
+
+func aliased(name string) string {
+	gobAliases.RLock()
+	defer gobAliases.RUnlock()
+	if n, ok := gobAliases.names[name]; ok {
+		return n
+	}
+	return name
+}
+
+// WriteGOBContainer writes a GOB container, with the header's Version, PackageVersion, Length and Checksum set.
+func WriteGOBContainer(w io.Writer, h GOBHeader, s Signal) error {
+	gs, err := toGOBSignal(s)
+	if err != nil {
+		return err
+	}
+	return writeGOBContainer(w, h, gs)
+}
+
+func writeGOBContainer(w io.Writer, h GOBHeader, gs gobSignal) error {
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(gs); err != nil {
+		return err
+	}
+	h.Version, h.PackageVersion = GOBContainerVersion, PackageVersion
+	h.Length, h.Checksum = uint64(payload.Len()), crc32.ChecksumIEEE(payload.Bytes())
+	h.FloatXY = floatXY
+	if _, err := io.WriteString(w, gobContainerMagic); err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(w).Encode(h); err != nil {
+		return err
+	}
+	_, err := payload.WriteTo(w)
+	return err
+}
+
+// ReadGOBContainer reads a GOB container.
+// x's and y's written by the other representation are converted, to the nearest this one has.
+// a bare Gob encoding, as written by WriteGOB, is also read, returned with a zero GOBHeader.
+func ReadGOBContainer(r io.Reader) (h GOBHeader, s Signal, err error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gobContainerMagic)); string(magic) != gobContainerMagic {
+		err = ReadGOB(br, &s)
+		return
+	}
+	br.Discard(len(gobContainerMagic))
+	if err = gob.NewDecoder(br).Decode(&h); err != nil {
+		return
+	}
+	if h.Version < 1 || h.Version > GOBContainerVersion {
+		return h, nil, errors.New(fmt.Sprintf("GOB container version %d not supported, (1 to %d.)", h.Version, GOBContainerVersion))
+	}
+	// the Length isn't trusted, the payload only grows as data is read.
+	if h.Length > math.MaxInt64 {
+		return h, nil, errors.New(fmt.Sprintf("GOB container length %d too large.", h.Length))
+	}
+	var buf bytes.Buffer
+	if n, err := io.CopyN(&buf, br, int64(h.Length)); err != nil {
+		if err == io.EOF {
+			err = errors.New(fmt.Sprintf("GOB container ran out, %d of %d bytes.", n, h.Length))
+		}
+		return h, nil, err
+	}
+	payload := buf.Bytes()
+	if crc32.ChecksumIEEE(payload) != h.Checksum {
+		return h, nil, errors.New("GOB container checksum mismatch.")
+	}
+	var gs gobSignal
+	if err = gob.NewDecoder(bytes.NewReader(payload)).Decode(&gs); err != nil {
+		return
+	}
+	s, err = gs.signalFrom(h.FloatXY)
+	return
+}
+
+// SaveGOBContainer saves a GOB container to a file, at exactly the path given.
+func SaveGOBContainer(pathTo string, h GOBHeader, s Signal) error {
+	file, err := os.Create(pathTo)
+	if err != nil {
+		return err
+	}
+	if err = WriteGOBContainer(file, h, s); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadGOBContainer loads a GOB container from a file, at exactly the path given.
+func LoadGOBContainer(pathTo string) (GOBHeader, Signal, error) {
+	file, err := os.Open(pathTo)
+	if err != nil {
+		return GOBHeader{}, nil, err
+	}
+	defer file.Close()
+	return ReadGOBContainer(file)
+}
+
+func toGOBSignal(s Signal) (gs gobSignal, err error) {
+	var values []interface{}
+	gs.Type, values, err = describe(s)
+	if err != nil {
+		return
+	}
+	for _, v := range values {
+		switch vt := v.(type) {
+		case Signal:
+			var c gobSignal
+			if c, err = toGOBSignal(vt); err != nil {
+				return
+			}
+			gs.Signals = append(gs.Signals, c)
+		case x:
+			if floatXY {
+				gs.Floats = append(gs.Floats, float64(vt))
+			} else {
+				gs.Ints = append(gs.Ints, int64(vt))
+			}
+		case y:
+			if floatXY {
+				gs.Floats = append(gs.Floats, float64(vt))
+			} else {
+				gs.Ints = append(gs.Ints, int64(vt))
+			}
+		case int:
+			gs.Ints = append(gs.Ints, int64(vt))
+		case Curve:
+			gs.Ints = append(gs.Ints, int64(vt))
+		case float32:
+			gs.Floats = append(gs.Floats, float64(vt))
+		case float64:
+			gs.Floats = append(gs.Floats, vt)
+		case bool:
+			gs.Bools = append(gs.Bools, vt)
+		case string:
+			gs.Strings = append(gs.Strings, vt)
+		case []byte:
+			gs.Bytes = append(gs.Bytes, vt)
+		case *big.Int:
+			b, _ := vt.GobEncode()
+			gs.Bytes = append(gs.Bytes, b)
+		}
+	}
+	return
+}
+
+func (gs gobSignal) signal() (Signal, error) {
+	return gs.signalFrom(floatXY)
+}
+
+// the Signal, with its x's and y's held as written by the representation with floatXY set to floats.
+func (gs gobSignal) signalFrom(floats bool) (Signal, error) {
+	name := aliased(gs.Type)
+	st, ok := signalTypes[name]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("unknown Signal type %q.", gs.Type))
+	}
+	var values []interface{}
+	short := errors.New(fmt.Sprintf("%s has too few parameters.", name))
+	for i, p := range st.parameters {
+		switch p.kind {
+		case signalKind, limitedSignalKind, periodicSignalKind:
+			n := 1
+			if st.variadic && i == len(st.parameters)-1 {
+				n = len(gs.Signals)
+			}
+			if len(gs.Signals) < n {
+				return nil, short
+			}
+			for _, c := range gs.Signals[:n] {
+				s, err := c.signalFrom(floats)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, s)
+			}
+			gs.Signals = gs.Signals[n:]
+		case xKind, periodKind, yKind:
+			if floats {
+				if len(gs.Floats) == 0 {
+					return nil, short
+				}
+				if p.kind == yKind {
+					values = append(values, yFromFloat(gs.Floats[0]))
+				} else {
+					values = append(values, xFromSeconds(gs.Floats[0]))
+				}
+				gs.Floats = gs.Floats[1:]
+				break
+			}
+			if len(gs.Ints) == 0 {
+				return nil, short
+			}
+			if p.kind == yKind {
+				values = append(values, fromFullScale(gs.Ints[0]))
+			} else {
+				values = append(values, xFromNanoseconds(gs.Ints[0]))
+			}
+			gs.Ints = gs.Ints[1:]
+		case intKind, curveKind:
+			if len(gs.Ints) == 0 {
+				return nil, short
+			}
+			if p.kind == curveKind {
+				if c := gs.Ints[0]; c < 0 || c > int64(SCurve) {
+					return nil, errors.New(fmt.Sprintf("Unsupported Curve (%d).", c))
+				}
+				values = append(values, Curve(gs.Ints[0]))
+			} else {
+				values = append(values, int(gs.Ints[0]))
+			}
+			gs.Ints = gs.Ints[1:]
+		case float32Kind, float64Kind:
+			if len(gs.Floats) == 0 {
+				return nil, short
+			}
+			if p.kind == float32Kind {
+				values = append(values, float32(gs.Floats[0]))
+			} else {
+				values = append(values, gs.Floats[0])
+			}
+			gs.Floats = gs.Floats[1:]
+		case boolKind:
+			if len(gs.Bools) == 0 {
+				return nil, short
+			}
+			values = append(values, gs.Bools[0])
+			gs.Bools = gs.Bools[1:]
+		case stringKind:
+			if len(gs.Strings) == 0 {
+				return nil, short
+			}
+			values = append(values, gs.Strings[0])
+			gs.Strings = gs.Strings[1:]
+		case bytesKind, bitsKind:
+			if len(gs.Bytes) == 0 {
+				return nil, short
+			}
+			if p.kind == bitsKind {
+				i := new(big.Int)
+				if err := i.GobDecode(gs.Bytes[0]); err != nil {
+					return nil, err
+				}
+				values = append(values, i)
+			} else {
+				values = append(values, gs.Bytes[0])
+			}
+			gs.Bytes = gs.Bytes[1:]
+		}
+	}
+	return makeSignal(name, values)
+}
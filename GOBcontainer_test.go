@@ -0,0 +1,159 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/gob"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGOBContainerRoundTrip(t *testing.T) {
+	for _, s := range builtins() {
+		var b bytes.Buffer
+		if err := WriteGOBContainer(&b, GOBHeader{SampleRate: 44100, SampleBytes: 2, Author: "me", Title: "test"}, s); err != nil {
+			t.Fatal(err)
+		}
+		h, s2, err := ReadGOBContainer(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Version != GOBContainerVersion || h.PackageVersion != PackageVersion || h.SampleRate != 44100 || h.SampleBytes != 2 || h.Author != "me" || h.Title != "test" {
+			t.Errorf("%+v", h)
+		}
+		text, _ := FormatSignal(s)
+		if text2, _ := FormatSignal(s2); text != text2 {
+			t.Errorf("%s != %s", text, text2)
+		}
+	}
+}
+
+func TestGOBContainerSaveLoad(t *testing.T) {
+	m := Stacked{Sine{unitX / 450}, Sine{unitX / 350}}
+	if err := SaveGOBContainer("./test output/stack.sgob", GOBHeader{Title: "stack"}, m); err != nil {
+		t.Fatal(err)
+	}
+	h, s, err := LoadGOBContainer("./test output/stack.sgob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Title != "stack" {
+		t.Error(h)
+	}
+	if e := MaxError(s, m, 0, unitX, unitX/1000); e != 0 {
+		t.Error(e)
+	}
+}
+
+func TestGOBContainerBare(t *testing.T) {
+	var b bytes.Buffer
+	m := Modulated{Sine{unitX / 400}, NewConstant(-6)}
+	if err := WriteGOB(&b, m); err != nil {
+		t.Fatal(err)
+	}
+	h, s, err := ReadGOBContainer(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Version != 0 {
+		t.Error(h)
+	}
+	if e := MaxError(s, m, 0, unitX, unitX/1000); e != 0 {
+		t.Error(e)
+	}
+}
+
+func TestGOBContainerErrors(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGOBContainer(&b, GOBHeader{}, Sine{unitX / 400}); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := b.Bytes()
+	corrupt[len(corrupt)-2]++
+	if _, _, err := ReadGOBContainer(bytes.NewReader(corrupt)); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Error(err)
+	}
+	if err := WriteGOBContainer(&b, GOBHeader{}, Inverted{unknownSignal{}}); err == nil {
+		t.Error("unknown type written.")
+	}
+	// truncated, and with a Length, (not trusted), far longer than its data.
+	if _, _, err := ReadGOBContainer(bytes.NewReader(corrupt[:len(corrupt)-4])); err == nil || !strings.Contains(err.Error(), "ran out") {
+		t.Error(err)
+	}
+	var h bytes.Buffer
+	h.WriteString(gobContainerMagic)
+	if err := gob.NewEncoder(&h).Encode(GOBHeader{Version: GOBContainerVersion, Length: 1 << 40}); err != nil {
+		t.Fatal(err)
+	}
+	h.WriteString("short")
+	if _, _, err := ReadGOBContainer(&h); err == nil || !strings.Contains(err.Error(), "ran out") {
+		t.Error(err)
+	}
+}
+
+func TestGOBContainerAlias(t *testing.T) {
+	var b bytes.Buffer
+	gs, _ := toGOBSignal(Shifted{Sine{unitX / 400}, unitX / 10})
+	gs.Type = "Delayed"
+	if err := writeGOBContainer(&b, GOBHeader{}, gs); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReadGOBContainer(bytes.NewReader(b.Bytes())); err == nil || !strings.Contains(err.Error(), "unknown Signal type") {
+		t.Error(err)
+	}
+	if err := RegisterGOBAlias("Delayed", "Shifted"); err != nil {
+		t.Fatal(err)
+	}
+	_, s, err := ReadGOBContainer(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sh, ok := s.(Shifted); !ok || sh.Shift != unitX/10 {
+		t.Errorf("%#v", s)
+	}
+	if err := RegisterGOBAlias("Sine", "Shifted"); err == nil {
+		t.Error("current type aliased.")
+	}
+}
+
+func TestGOBContainerOtherRepresentation(t *testing.T) {
+	// as written by each representation, a Stacked of a Sine and a half Constant.
+	written := map[bool]gobSignal{
+		false: {Type: "Stacked", Signals: []gobSignal{{Type: "Sine", Ints: []int64{2500000}}, {Type: "Constant", Ints: []int64{math.MaxInt64 / 2}}}},
+		true:  {Type: "Stacked", Signals: []gobSignal{{Type: "Sine", Floats: []float64{0.0025}}, {Type: "Constant", Floats: []float64{0.5}}}},
+	}
+	for floats, gs := range written {
+		var b bytes.Buffer
+		gobWriteAs(t, &b, floats, gs)
+		_, s, err := ReadGOBContainer(&b)
+		if err != nil {
+			t.Fatal(floats, err)
+		}
+		st, ok := s.(Stacked)
+		if !ok || len(st) != 2 {
+			t.Fatalf("%v %#v", floats, s)
+		}
+		if st[0] != (Sine{unitX / 400}) {
+			t.Errorf("%v %#v", floats, st[0])
+		}
+		if c, ok := st[1].(Constant); !ok || math.Abs(float64(c.Constant)/float64(unitY)-0.5) > 1e-9 {
+			t.Errorf("%v %#v", floats, st[1])
+		}
+	}
+}
+
+// writes a GOB container with its header claiming the given representation.
+func gobWriteAs(t *testing.T, w io.Writer, floats bool, gs gobSignal) {
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(gs); err != nil {
+		t.Fatal(err)
+	}
+	h := GOBHeader{Version: GOBContainerVersion, FloatXY: floats, Length: uint64(payload.Len()), Checksum: crc32.ChecksumIEEE(payload.Bytes())}
+	io.WriteString(w, gobContainerMagic)
+	if err := gob.NewEncoder(w).Encode(h); err != nil {
+		t.Fatal(err)
+	}
+	payload.WriteTo(w)
+}
@@ -0,0 +1,231 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+/*
+JSON encoding of a Signal.
+
+A Signal is an object with a "Type" member, its type name, and a member for each of its parameters, named as in the text form, (see ParseSignal) with x and y values as text form strings, so they are exact and can have units. (numbers are also accepted, as seconds or fractions of the maximum.) Combiners have an array of Signals.
+
+	{"Type":"Modulated","Signals":[{"Type":"Sine","Cycle":"400Hz"},{"Type":"Constant","Constant":"-6dB"}]}
+
+WriteJSON/ReadJSON wrap this in a document with the format version;
+
+	{"Version":1,"Signal":{...}}
+
+JSON is a subset of YAML, so these can be read as YAML, and YAML with the same structure can be converted to JSON to be read.
+*/
+
+// the JSON format version written, and the highest that can be read.
+const JSONVersion = 1
+
+// JSONSignal wraps a Signal so it can be encoded as, and decoded from, JSON, on its own or as part of other JSON.
+type JSONSignal struct {
+	Signal
+}
+
+func (s JSONSignal) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	if err := encodeJSON(&b, s.Signal); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (s *JSONSignal) UnmarshalJSON(b []byte) (err error) {
+	s.Signal, err = decodeJSON(b, "Signal")
+	return
+}
+
+type jsonDocument struct {
+	Version int
+	Signal  JSONSignal
+}
+
+// write versioned JSON encoding
+func WriteJSON(w io.Writer, s Signal) error {
+	b, err := json.MarshalIndent(jsonDocument{JSONVersion, JSONSignal{s}}, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// read versioned JSON encoding
+func ReadJSON(r io.Reader, s *Signal) error {
+	var doc struct {
+		Version int
+		Signal  json.RawMessage
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+	if doc.Version < 1 || doc.Version > JSONVersion {
+		return errors.New(fmt.Sprintf("JSON format version %d not supported, (1 to %d.)", doc.Version, JSONVersion))
+	}
+	if doc.Signal == nil {
+		return errors.New("JSON has no Signal.")
+	}
+	d, err := decodeJSON(doc.Signal, "Signal")
+	if err != nil {
+		return err
+	}
+	*s = d
+	return nil
+}
+
+func encodeJSON(b *bytes.Buffer, s Signal) error {
+	name, values, err := describe(s)
+	if err != nil {
+		return err
+	}
+	st := signalTypes[name]
+	b.WriteString(`{"Type":`)
+	b.WriteString(strconv.Quote(name))
+	for i, p := range st.parameters {
+		b.WriteString(",")
+		b.WriteString(strconv.Quote(p.name))
+		b.WriteString(":")
+		if st.variadic && i == len(st.parameters)-1 {
+			b.WriteString("[")
+			for j, v := range values[i:] {
+				if j > 0 {
+					b.WriteString(",")
+				}
+				if err := encodeJSONValue(b, v, p.kind); err != nil {
+					return err
+				}
+			}
+			b.WriteString("]")
+			break
+		}
+		if err := encodeJSONValue(b, values[i], p.kind); err != nil {
+			return err
+		}
+	}
+	b.WriteString("}")
+	return nil
+}
+
+func encodeJSONValue(b *bytes.Buffer, v interface{}, k kind) error {
+	switch k {
+	case signalKind, limitedSignalKind, periodicSignalKind:
+		return encodeJSON(b, v.(Signal))
+	case xKind, periodKind, yKind, bitsKind, curveKind:
+		b.WriteString(strconv.Quote(formatValue(v, k)))
+		return nil
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b.Write(j)
+	return nil
+}
+
+// path is used to locate errors within the JSON.
+func decodeJSON(data []byte, path string) (Signal, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	var name string
+	if err := json.Unmarshal(members["Type"], &name); err != nil || name == "" {
+		return nil, errors.New(path + ": missing Type.")
+	}
+	st, ok := signalTypes[name]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%s: unknown Signal type %q.", path, name))
+	}
+	delete(members, "Type")
+	var values []interface{}
+	for i, p := range st.parameters {
+		raw, ok := members[p.name]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%s: %s missing %s.", path, name, p.name))
+		}
+		delete(members, p.name)
+		if st.variadic && i == len(st.parameters)-1 {
+			var raws []json.RawMessage
+			if err := json.Unmarshal(raw, &raws); err != nil {
+				return nil, errors.New(fmt.Sprintf("%s.%s: %s", path, p.name, err.Error()))
+			}
+			for j := range raws {
+				v, err := decodeJSONValue(raws[j], p.kind, fmt.Sprintf("%s.%s[%d]", path, p.name, j))
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			break
+		}
+		v, err := decodeJSONValue(raw, p.kind, path+"."+p.name)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	for m := range members {
+		return nil, errors.New(fmt.Sprintf("%s: %s has no %s.", path, name, m))
+	}
+	s, err := makeSignal(name, values)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return s, nil
+}
+
+func decodeJSONValue(raw json.RawMessage, k kind, path string) (v interface{}, err error) {
+	switch k {
+	case signalKind, limitedSignalKind, periodicSignalKind:
+		v, err = decodeJSON(raw, path)
+		if err != nil {
+			return nil, err
+		}
+		err = k.check(v)
+	case xKind, periodKind, yKind, bitsKind, curveKind:
+		text := string(raw)
+		if len(raw) > 0 && raw[0] == '"' {
+			err = json.Unmarshal(raw, &text)
+		}
+		if err == nil {
+			v, err = parseValue(text, k)
+		}
+	case intKind:
+		var i int
+		err = json.Unmarshal(raw, &i)
+		v = i
+	case float32Kind:
+		var f float32
+		err = json.Unmarshal(raw, &f)
+		v = f
+	case float64Kind:
+		var f float64
+		err = json.Unmarshal(raw, &f)
+		v = f
+	case boolKind:
+		var b bool
+		err = json.Unmarshal(raw, &b)
+		v = b
+	case stringKind:
+		var s string
+		err = json.Unmarshal(raw, &s)
+		v = s
+	case bytesKind:
+		var d []byte
+		err = json.Unmarshal(raw, &d)
+		v = d
+	}
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	return
+}
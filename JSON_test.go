@@ -0,0 +1,101 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, s := range builtins() {
+		var b bytes.Buffer
+		if err := WriteJSON(&b, s); err != nil {
+			t.Fatal(err)
+		}
+		var s2 Signal
+		if err := ReadJSON(&b, &s2); err != nil {
+			t.Fatal(err)
+		}
+		text, _ := FormatSignal(s)
+		text2, _ := FormatSignal(s2)
+		if text != text2 {
+			t.Errorf("%s != %s", text, text2)
+		}
+	}
+}
+
+func TestJSONSaveLoad(t *testing.T) {
+	m := Modulated{Looped{Pulse{unitX * 375 / 1000}, unitX * 75 / 100}, NewADSREnvelope(unitX/10, unitX/10, unitX/2, unitY/2, unitX/10), Sine{unitX / 400}}
+	file, err := os.Create("./test output/BusyTone.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteJSON(file, m)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err = os.Open("./test output/BusyTone.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var s Signal
+	if err = ReadJSON(file, &s); err != nil {
+		t.Fatal(err)
+	}
+	if e := MaxError(m, s, 0, unitX, unitX/8000); e > roundingError {
+		t.Error(e)
+	}
+}
+
+func TestJSONEmbedded(t *testing.T) {
+	type tone struct {
+		Name   string
+		Signal JSONSignal
+	}
+	b, err := json.Marshal(tone{"dial", JSONSignal{Stacked{Sine{unitX / 450}, Sine{unitX / 350}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Name":"dial","Signal":{"Type":"Stacked","Signals":[{"Type":"Sine","Cycle":"450Hz"},{"Type":"Sine","Cycle":"0.002857142"}]}}`
+	if floatXY {
+		// any frequency is exact.
+		want = strings.Replace(want, `"0.002857142"`, `"350Hz"`, 1)
+	}
+	if string(b) != want {
+		t.Error(string(b))
+	}
+	var d tone
+	if err := json.Unmarshal([]byte(`{"Name":"a","Signal":{"Type":"Modulated","Signals":[{"Type":"Sine","Cycle":0.0025},{"Type":"Constant","Constant":0.5}]}}`), &d); err != nil {
+		t.Fatal(err)
+	}
+	if e := MaxError(d.Signal, Modulated{Sine{unitX / 400}, Constant{unitY / 2}}, 0, unitX, unitX/8000); e != 0 {
+		t.Error(e)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for doc, message := range map[string]string{
+		`{"Version":2,"Signal":{"Type":"Sine","Cycle":"1"}}`: "version 2 not supported",
+		`{"Version":1}`:                                                                                 "no Signal",
+		`{"Version":1,"Signal":{"Cycle":"1"}}`:                                                          "Signal: missing Type",
+		`{"Version":1,"Signal":{"Type":"Cosine","Cycle":"1"}}`:                                          `Signal: unknown Signal type "Cosine"`,
+		`{"Version":1,"Signal":{"Type":"Sine"}}`:                                                        "Signal: Sine missing Cycle",
+		`{"Version":1,"Signal":{"Type":"Sine","Cycle":"1","Phase":"1"}}`:                                "Signal: Sine has no Phase",
+		`{"Version":1,"Signal":{"Type":"Sine","Cycle":"one"}}`:                                          "Signal.Cycle: ",
+		`{"Version":1,"Signal":{"Type":"Stacked","Signals":[{"Type":"Sine","Cycle":1},{"Type":"X"}]}}`:  `Signal.Signals[1]: unknown Signal type "X"`,
+		`{"Version":1,"Signal":{"Type":"Offset","LimitedSignal":{"Type":"Sine","Cycle":1},"Offset":0}}`: "Signal.LimitedSignal: signals.Sine is not a LimitedSignal",
+	} {
+		var s Signal
+		err := ReadJSON(strings.NewReader(doc), &s)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("%s: %v, expected %q", doc, err, message)
+		}
+	}
+	if err := WriteJSON(&bytes.Buffer{}, Inverted{unknownSignal{}}); err == nil {
+		t.Error("unknown type encoded.")
+	}
+}
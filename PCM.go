@@ -98,7 +98,7 @@ func NewPCM8bit(sampleRate uint32, Data []byte) PCM8bit {
 }
 
 func (s PCM8bit) property(p x) y {
-	index := int(p / s.samplePeriod)
+	index := sampleIndex(p, s.samplePeriod)
 	if index < 0 || index >= len(s.Data){
 		return 0
 	}
@@ -107,11 +107,11 @@ func (s PCM8bit) property(p x) y {
 
 
 func encodePCM8bit(v y) byte {
-	return byte(v>>(yBits-8)) + 128
+	return byte(fullScale(v)>>(yBits-8)) + 128
 }
 
 func decodePCM8bit(b byte) y {
-	return y(b-128) << (yBits-8)
+	return fromFullScale(int64(b-128) << (yBits-8))
 }
 
 func (s PCM8bit) MaxX() x {
@@ -123,7 +123,7 @@ func (s PCM8bit) Encode(w io.Writer) {
 }
 
 func (s PCM8bit) Split(p x) (PCM8bit, PCM8bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 1)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 1)
 	return PCM8bit{head}, PCM8bit{tail}
 }
 
@@ -137,7 +137,7 @@ func NewPCM16bit(sampleRate uint32, Data []byte) PCM16bit {
 }
 
 func (s PCM16bit) property(p x) y {
-	index := int(p/s.samplePeriod) * 2
+	index := sampleIndex(p, s.samplePeriod) * 2
 	if index < 0 || index >= len(s.Data)-1 {
 		return 0
 	}
@@ -145,11 +145,11 @@ func (s PCM16bit) property(p x) y {
 }
 
 func encodePCM16bit(v y) (byte, byte) {
-	return byte(v >> (yBits - 16)), byte(v >> (yBits - 8))
+	return byte(fullScale(v) >> (yBits - 16)), byte(fullScale(v) >> (yBits - 8))
 }
 
 func decodePCM16bit(b1, b2 byte) y {
-	return y(b1) << (yBits-16)|y(b2) << (yBits-8)
+	return fromFullScale(int64(b1) << (yBits-16)|int64(b2) << (yBits-8))
 }
 
 func (s PCM16bit) Encode(w io.Writer) {
@@ -160,7 +160,7 @@ func (s PCM16bit) MaxX() x {
 }
 
 func (s PCM16bit) Split(p x) (PCM16bit, PCM16bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 2)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 2)
 	return PCM16bit{head}, PCM16bit{tail}
 }
 
@@ -174,17 +174,17 @@ func NewPCM24bit(sampleRate uint32, Data []byte) PCM24bit {
 }
 
 func (s PCM24bit) property(p x) y {
-	index := int(p/s.samplePeriod) * 3
+	index := sampleIndex(p, s.samplePeriod) * 3
 	if index < 0 || index >= len(s.Data)-2 {
 		return 0
 	}
 	return decodePCM24bit(s.Data[index], s.Data[index+1], s.Data[index+2])
 }
 func encodePCM24bit(v y) (byte, byte, byte) {
-	return byte(v >> (yBits - 24)), byte(v >> (yBits - 16)), byte(v >> (yBits - 8))
+	return byte(fullScale(v) >> (yBits - 24)), byte(fullScale(v) >> (yBits - 16)), byte(fullScale(v) >> (yBits - 8))
 }
 func decodePCM24bit(b1, b2, b3 byte) y {
-	return y(b1) << (yBits-24)|y(b2) << (yBits-16)|y(b3) << (yBits-8)
+	return fromFullScale(int64(b1) << (yBits-24)|int64(b2) << (yBits-16)|int64(b3) << (yBits-8))
 }
 
 func (s PCM24bit) Encode(w io.Writer) {
@@ -195,7 +195,7 @@ func (s PCM24bit) MaxX() x {
 }
 
 func (s PCM24bit) Split(p x) (PCM24bit, PCM24bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 3)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 3)
 	return PCM24bit{head}, PCM24bit{tail}
 }
 
@@ -209,17 +209,17 @@ func NewPCM32bit(sampleRate uint32, Data []byte) PCM32bit {
 }
 
 func (s PCM32bit) property(p x) y {
-	index := int(p/s.samplePeriod) * 4
+	index := sampleIndex(p, s.samplePeriod) * 4
 	if index < 0 || index >= len(s.Data)-3 {
 		return 0
 	}
 	return decodePCM32bit(s.Data[index], s.Data[index+1], s.Data[index+2], s.Data[index+3])
 }
 func encodePCM32bit(v y) (byte, byte, byte, byte) {
-	return byte(v >> (yBits - 32)), byte(v >> (yBits - 24)), byte(v >> (yBits - 16)), byte(v >> (yBits - 8))
+	return byte(fullScale(v) >> (yBits - 32)), byte(fullScale(v) >> (yBits - 24)), byte(fullScale(v) >> (yBits - 16)), byte(fullScale(v) >> (yBits - 8))
 }
 func decodePCM32bit(b1, b2, b3, b4 byte) y {
-	return y(b1) << (yBits-32)|y(b2) << (yBits-24)|y(b3) << (yBits-16)|y(b4) << (yBits-8)
+	return fromFullScale(int64(b1) << (yBits-32)|int64(b2) << (yBits-24)|int64(b3) << (yBits-16)|int64(b4) << (yBits-8))
 }
 
 func (s PCM32bit) Encode(w io.Writer) {
@@ -230,7 +230,7 @@ func (s PCM32bit) MaxX() x {
 }
 
 func (s PCM32bit) Split(p x) (PCM32bit, PCM32bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 4)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 4)
 	return PCM32bit{head}, PCM32bit{tail}
 }
 
@@ -244,17 +244,17 @@ func NewPCM48bit(sampleRate uint32, Data []byte) PCM48bit {
 }
 
 func (s PCM48bit) property(p x) y {
-	index := int(p/s.samplePeriod) * 6
+	index := sampleIndex(p, s.samplePeriod) * 6
 	if index < 0 || index >= len(s.Data)-5 {
 		return 0
 	}
 	return decodePCM48bit(s.Data[index], s.Data[index+1], s.Data[index+2], s.Data[index+3], s.Data[index+4], s.Data[index+5])
 }
 func encodePCM48bit(v y) (byte, byte, byte, byte, byte, byte) {
-	return byte(v >> (yBits - 48)), byte(v >> (yBits - 40)), byte(v >> (yBits - 32)), byte(v >> (yBits - 24)), byte(v >> (yBits - 16)), byte(v >> (yBits - 8))
+	return byte(fullScale(v) >> (yBits - 48)), byte(fullScale(v) >> (yBits - 40)), byte(fullScale(v) >> (yBits - 32)), byte(fullScale(v) >> (yBits - 24)), byte(fullScale(v) >> (yBits - 16)), byte(fullScale(v) >> (yBits - 8))
 }
 func decodePCM48bit(b1, b2, b3, b4, b5, b6 byte) y {
-	return y(b1) << (yBits-48)|y(b2) << (yBits-40)|y(b3) << (yBits-32)|y(b4) << (yBits-24)|y(b5) << (yBits-16)|y(b6) << (yBits-8)
+	return fromFullScale(int64(b1) << (yBits-48)|int64(b2) << (yBits-40)|int64(b3) << (yBits-32)|int64(b4) << (yBits-24)|int64(b5) << (yBits-16)|int64(b6) << (yBits-8))
 }
 
 func (s PCM48bit) Encode(w io.Writer) {
@@ -265,7 +265,7 @@ func (s PCM48bit) MaxX() x {
 }
 
 func (s PCM48bit) Split(p x) (PCM48bit, PCM48bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 6)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 6)
 	return PCM48bit{head}, PCM48bit{tail}
 }
 
@@ -279,17 +279,17 @@ func NewPCM64bit(sampleRate uint32, Data []byte) PCM64bit {
 }
 
 func (s PCM64bit) property(p x) y {
-	index := int(p/s.samplePeriod) * 8
+	index := sampleIndex(p, s.samplePeriod) * 8
 	if index < 0 || index >= len(s.Data)-7 {
 		return 0
 	}
 	return decodePCM64bit(s.Data[index], s.Data[index+1], s.Data[index+2], s.Data[index+3], s.Data[index+4], s.Data[index+5], s.Data[index+6], s.Data[index+7])
 }
 func encodePCM64bit(v y) (byte, byte, byte, byte, byte, byte, byte, byte) {
-	return byte(v >> (yBits - 64)), byte(v >> (yBits - 56)),byte(v >> (yBits - 48)), byte(v >> (yBits - 40)), byte(v >> (yBits - 32)), byte(v >> (yBits - 24)), byte(v >> (yBits - 16)), byte(v >> (yBits - 8))
+	return byte(fullScale(v) >> (yBits - 64)), byte(fullScale(v) >> (yBits - 56)),byte(fullScale(v) >> (yBits - 48)), byte(fullScale(v) >> (yBits - 40)), byte(fullScale(v) >> (yBits - 32)), byte(fullScale(v) >> (yBits - 24)), byte(fullScale(v) >> (yBits - 16)), byte(fullScale(v) >> (yBits - 8))
 }
 func decodePCM64bit(b1, b2, b3, b4, b5, b6 , b7, b8 byte) y {
-	return y(b1) << (yBits-64)|y(b1) << (yBits-56)|y(b1) << (yBits-48)|y(b2) << (yBits-40)|y(b3) << (yBits-32)|y(b4) << (yBits-24)|y(b5) << (yBits-16)|y(b6) << (yBits-8)
+	return fromFullScale(int64(b1) << (yBits-64)|int64(b2) << (yBits-56)|int64(b3) << (yBits-48)|int64(b4) << (yBits-40)|int64(b5) << (yBits-32)|int64(b6) << (yBits-24)|int64(b7) << (yBits-16)|int64(b8) << (yBits-8))
 }
 
 func (s PCM64bit) Encode(w io.Writer) {
@@ -300,19 +300,13 @@ func (s PCM64bit) MaxX() x {
 }
 
 func (s PCM64bit) Split(p x) (PCM64bit, PCM64bit) {
-	head, tail := s.PCM.Split(uint32(p/s.PCM.samplePeriod)+1, 8)
+	head, tail := s.PCM.Split(uint32(sampleIndex(p, s.PCM.samplePeriod))+1, 8)
 	return PCM64bit{head}, PCM64bit{tail}
 }
 
-// make a PeriodicLimitedSignal by sampling from a Signal, using provided parameters.
+// make a PeriodicLimitedSignal by sampling from a Signal, using provided parameters. (see Freeze for errors.)
 func NewPCMSignal(s Signal, length x, sampleRate uint32, sampleBytes uint8) PeriodicLimitedSignal {
-	out, in := io.Pipe()
-	go func() {
-		Encode(in, sampleBytes, sampleRate, length, s)
-		in.Close()
-	}()
-	channels, _ := Decode(out)
-	out.Close()
-	return channels[0]
+	p, _ := Freeze(s, length, sampleRate, sampleBytes, false)
+	return p
 }
 
@@ -8,6 +8,9 @@ import (
 )
 
 func TestPCMscale(t *testing.T) {
+	if floatXY {
+		t.Skip("int64 y's, one bit below full scale.")
+	}
 
 	if decodePCM8bit(0x80)!=Y(0){t.Error(decodePCM8bit(0x80))}
 	if decodePCM8bit(0xFF)+decodePCM8bit(0x81)-1!=Y(1){t.Error(Y(-1),decodePCM8bit(0xFF)+decodePCM8bit(0x81)-1)}
@@ -28,6 +31,14 @@ func TestPCMscale(t *testing.T) {
 	
 }
 
+// 64bit samples, with the two least significant bytes zero, are the same as 48bit ones.
+func TestPCMDecode64bit(t *testing.T) {
+	b := []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0x3c}
+	if v, v48 := decodePCM64bit(0, 0, b[0], b[1], b[2], b[3], b[4], b[5]), decodePCM48bit(b[0], b[1], b[2], b[3], b[4], b[5]); v != v48 {
+		t.Error(v, v48)
+	}
+}
+
 func TestPCMRaw(t *testing.T) {
 	var file *os.File
 	var err error
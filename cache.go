@@ -1,36 +1,307 @@
 package signals
 
+import (
+	"bytes"
+	"container/list"
+	"encoding/gob"
+	"math"
+	"runtime"
+	"sync"
+)
+
+func init() {
+	gob.Register(Cached{})
+	gob.Register(Buffered{})
+}
+
+// default number of property values a Cached keeps.
 const cacheSize = 256
 
 // a Signal that stores and reuses, some, recent property values, rather than always getting them from the embedded Signal.
+// the least recently used values are dropped, to keep at most Size values.
+// if Quantum isn't zero, x's are rounded down to a multiple of it, so nearby x's share a value, (the embedded Signal's at the rounded down x.)
+// use NewCached, a Cached literal has no cache, so just passes through the embedded Signal.
 type Cached struct {
 	Signal
-	cache map[x] y
+	Size    int
+	Quantum x
+	cache   *lru
+}
+
+// least recently used cache, locked, shared by copies of a Cached.
+type lru struct {
+	sync.Mutex
+	values       map[x]*list.Element
+	order        *list.List // most recently used at the front.
+	hits, misses uint64
+}
+
+type cacheEntry struct {
+	p x
+	v y
+}
+
+// NewCached returns a Cached, with an empty cache, size zero being the default size.
+func NewCached(s Signal, size int, quantum x) Cached {
+	return Cached{s, size, quantum, &lru{values: make(map[x]*list.Element), order: list.New()}}
 }
 
 func (s Cached) property(p x) y {
-	if v,ok:=s.cache[p];ok {return v}
-	if len(s.cache)>cacheSize+10{
-		for i:=range(s.cache){
-			delete(s.cache,i)
-			if len(s.cache)<=cacheSize{break}
+	if s.Quantum > 0 {
+		if r := modX(p, s.Quantum); r < 0 {
+			p -= r + s.Quantum
+		} else {
+			p -= r
 		}
 	}
-	v:=s.Signal.property(p)
-	s.cache[p]=v
+	if s.cache == nil {
+		return s.Signal.property(p)
+	}
+	c := s.cache
+	c.Lock()
+	if e, ok := c.values[p]; ok {
+		c.order.MoveToFront(e)
+		c.hits++
+		c.Unlock()
+		return e.Value.(cacheEntry).v
+	}
+	c.misses++
+	c.Unlock()
+	// not locked while getting the value, so slow Signals don't hold up others.
+	v := s.Signal.property(p)
+	c.Lock()
+	if _, ok := c.values[p]; !ok {
+		c.values[p] = c.order.PushFront(cacheEntry{p, v})
+		size := s.Size
+		if size <= 0 {
+			size = cacheSize
+		}
+		for c.order.Len() > size {
+			delete(c.values, c.order.Remove(c.order.Back()).(cacheEntry).p)
+		}
+	}
+	c.Unlock()
 	return v
 }
 
-// a Signal that stores and reuses, sequential and evenly spaced, recent property values, rather than always getting them from the embedded Signal.
-//type Buffered struct {
-//	Shifted
-//	reader io.Reader
-//}
-//
-//func NewBuffered(s LimitedSignal,sampleBytes uint8,sampleRate uint32) Buffered {
-//	r, w := io.Pipe()
-//	Encode(w, sampleBytes, sampleRate, s.MaxX(), s)
-//	return Buffered{Shifted{s,0},r}
-//}
-//
+// Stats returns the number of property values got from the cache, and not.
+func (s Cached) Stats() (hits, misses uint64) {
+	if s.cache == nil {
+		return
+	}
+	s.cache.Lock()
+	defer s.cache.Unlock()
+	return s.cache.hits, s.cache.misses
+}
+
+// the parameters, without the cache.
+type cachedGOB struct {
+	Signal  Signal
+	Size    int
+	Quantum x
+}
+
+// GobEncode encodes the parameters, not the cache.
+func (s Cached) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(cachedGOB{s.Signal, s.Size, s.Quantum})
+	return b.Bytes(), err
+}
+
+// GobDecode makes a Cached with an empty cache.
+func (s *Cached) GobDecode(data []byte) error {
+	var c cachedGOB
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&c); err != nil {
+		return err
+	}
+	*s = NewCached(c.Signal, c.Size, c.Quantum)
+	return nil
+}
+
+// a Signal that stores and reuses, sequential and evenly spaced, property values, rendered ahead, in blocks, on a background goroutine, rather than getting them from the embedded Signal when needed.
+// x's that are a multiple of Period, and are in a block rendered, or being rendered, are from the buffer, others are got from the embedded Signal directly, and if not in, or just after, the blocks rendered, restart rendering from there.
+// Ahead blocks, of Block values, are rendered beyond the last used.
+// use NewBuffered, a Buffered literal has no buffer, so just passes through the embedded Signal.
+// Close stops rendering, otherwise it's stopped when the Buffered, and all its copies, are garbage collected, (as for those made by ParseSignal, ReadJSON or GOB decoding.)
+type Buffered struct {
+	Signal
+	Period x
+	Block  int
+	Ahead  int
+	buffer *bufferHandle
+}
+
+// the blockBuffer, shared by copies of a Buffered, but not by the goroutine rendering it, so it can be finalized, closing it, when they've all gone.
+type bufferHandle struct {
+	*blockBuffer
+}
+
+// rendered blocks, shared by copies of a Buffered.
+type blockBuffer struct {
+	sync.Mutex
+	rendered     *sync.Cond // broadcast when a block is rendered, the blocks wanted change, or closed.
+	blocks       map[int64][]y
+	read         int64 // block last read
+	next         int64 // block to render next
+	generation   int   // changed when restarted, so blocks rendering aren't kept.
+	closed       bool
+	started      bool
+	hits, misses uint64
+	signal       sync.Mutex // held while getting the embedded Signal's values, if it isn't ConcurrentSafe.
+}
 
+// NewBuffered returns a Buffered, rendering starts when first used.
+func NewBuffered(s Signal, period x, block, ahead int) Buffered {
+	if block < 1 {
+		block = 1
+	}
+	if ahead < 1 {
+		ahead = 1
+	}
+	b := &blockBuffer{blocks: make(map[int64][]y)}
+	b.rendered = sync.NewCond(b)
+	h := &bufferHandle{b}
+	runtime.SetFinalizer(h, func(h *bufferHandle) { h.close() })
+	return Buffered{s, period, block, ahead, h}
+}
+
+func (s Buffered) property(p x) y {
+	if s.buffer == nil || s.Period <= 0 {
+		return s.value(p)
+	}
+	i := int64(math.Round(float64(p) / float64(s.Period)))
+	if x(i)*s.Period != p {
+		return s.value(p)
+	}
+	bi := i / int64(s.Block)
+	if i%int64(s.Block) < 0 {
+		bi--
+	}
+	b := s.buffer
+	b.Lock()
+	if !b.started && !b.closed {
+		b.started = true
+		b.read, b.next = bi, bi
+		go b.render(s.Signal, s.Period, s.Block, s.Ahead)
+	}
+	// read on, to a block rendered or being rendered, lets rendering go on.
+	if bi > b.read && bi <= b.next {
+		b.read = bi
+		for k := range b.blocks {
+			if k < bi {
+				delete(b.blocks, k)
+			}
+		}
+		b.rendered.Broadcast()
+	}
+	// the block being rendered, wait for it.
+	for generation := b.generation; bi == b.next && generation == b.generation && !b.closed; {
+		b.rendered.Wait()
+	}
+	if block, ok := b.blocks[bi]; ok {
+		b.hits++
+		b.Unlock()
+		return block[i-bi*int64(s.Block)]
+	}
+	b.misses++
+	if bi < b.read || bi > b.next {
+		// restart from here.
+		b.read, b.next = bi, bi
+		b.generation++
+		b.blocks = make(map[int64][]y)
+		b.rendered.Broadcast()
+	}
+	b.Unlock()
+	return s.value(p)
+}
+
+// the embedded Signal's value.
+func (s Buffered) value(p x) y {
+	if s.buffer == nil {
+		return s.Signal.property(p)
+	}
+	return s.buffer.value(s.Signal, p)
+}
+
+// a Signal's value, locked if it isn't ConcurrentSafe.
+func (b *blockBuffer) value(s Signal, p x) y {
+	if !IsConcurrentSafe(s) {
+		b.signal.Lock()
+		defer b.signal.Unlock()
+	}
+	return s.property(p)
+}
+
+// render blocks, of a Signal, ahead of the last read, until closed.
+func (b *blockBuffer) render(s Signal, period x, size, ahead int) {
+	b.Lock()
+	defer b.Unlock()
+	for !b.closed {
+		if b.next > b.read+int64(ahead) {
+			b.rendered.Wait()
+			continue
+		}
+		bi, generation := b.next, b.generation
+		b.Unlock()
+		block := make([]y, size)
+		for j := range block {
+			block[j] = b.value(s, x(bi*int64(size)+int64(j))*period)
+		}
+		b.Lock()
+		if generation == b.generation {
+			b.blocks[bi] = block
+			b.next++
+			b.rendered.Broadcast()
+		}
+	}
+}
+
+// Close stops background rendering, values are then got directly from the embedded Signal.
+func (s Buffered) Close() {
+	if s.buffer == nil {
+		return
+	}
+	s.buffer.close()
+}
+
+func (b *blockBuffer) close() {
+	b.Lock()
+	b.closed = true
+	b.blocks = make(map[int64][]y)
+	b.rendered.Broadcast()
+	b.Unlock()
+}
+
+// Stats returns the number of property values, that are multiples of Period, got from the buffer, and not.
+func (s Buffered) Stats() (hits, misses uint64) {
+	if s.buffer == nil {
+		return
+	}
+	s.buffer.Lock()
+	defer s.buffer.Unlock()
+	return s.buffer.hits, s.buffer.misses
+}
+
+// the parameters, without the buffer.
+type bufferedGOB struct {
+	Signal       Signal
+	Period       x
+	Block, Ahead int
+}
+
+// GobEncode encodes the parameters, not the buffer.
+func (s Buffered) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(bufferedGOB{s.Signal, s.Period, s.Block, s.Ahead})
+	return b.Bytes(), err
+}
+
+// GobDecode makes a Buffered with an empty buffer.
+func (s *Buffered) GobDecode(data []byte) error {
+	var c bufferedGOB
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&c); err != nil {
+		return err
+	}
+	*s = NewBuffered(c.Signal, c.Period, c.Block, c.Ahead)
+	return nil
+}
@@ -1,10 +1,13 @@
 package signals
 
 import (
+	"bytes"
 	"os"
 	"testing"
 	"net"
 	"net/url"
+	"runtime"
+	"time"
 )
 
 func TestCacheStreamsSave(t *testing.T) {
@@ -22,7 +25,7 @@ func TestCacheStreamsSave(t *testing.T) {
 		t.Fatal(err)
 	}
 	//t.Logf("%v\n",s)
-	fs:=Cached{s,make(map[x]y)}
+	fs:=NewCached(s,0,0)
 	file, err := os.Create("./test output/cachedStream.wav")
 	if err != nil {panic(err)}
 	Encode(file, 1, 8000, unitX*3, fs)
@@ -32,3 +35,157 @@ func TestCacheStreamsSave(t *testing.T) {
 
 
 
+
+func TestCachedLRU(t *testing.T) {
+	s := NewCached(Sine{unitX}, 3, 0)
+	for _, p := range []x{1, 2, 3, 1, 4, 1, 2} {
+		if s.property(p*unitX/10) != (Sine{unitX}).property(p*unitX/10) {
+			t.Error(p)
+		}
+	}
+	// 2 was least recently used when 4 was added.
+	if hits, misses := s.Stats(); hits != 2 || misses != 5 {
+		t.Error(hits, misses)
+	}
+	if len(s.cache.values) != 3 || s.cache.order.Len() != 3 {
+		t.Error(len(s.cache.values))
+	}
+}
+
+func TestCachedQuantum(t *testing.T) {
+	s := NewCached(RampUp{unitX}, 0, unitX/10)
+	if s.property(unitX*15/100) != (RampUp{unitX}).property(unitX/10) || s.property(unitX*19/100) != (RampUp{unitX}).property(unitX/10) {
+		t.Error("not quantized.")
+	}
+	if s.property(-unitX/100) != (RampUp{unitX}).property(-unitX/10) {
+		t.Error("negative not rounded down.")
+	}
+	if hits, misses := s.Stats(); hits != 1 || misses != 2 {
+		t.Error(hits, misses)
+	}
+}
+
+func TestCachedLiteral(t *testing.T) {
+	s := Cached{Signal: Sine{unitX}}
+	if s.property(unitX/4) != (Sine{unitX}).property(unitX/4) {
+		t.Error(s.property(unitX / 4))
+	}
+	if hits, misses := s.Stats(); hits != 0 || misses != 0 {
+		t.Error(hits, misses)
+	}
+}
+
+func TestCachedGOB(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGOB(&b, NewCached(Sine{unitX}, 10, unitX/1000)); err != nil {
+		t.Fatal(err)
+	}
+	var s Signal
+	if err := ReadGOB(&b, &s); err != nil {
+		t.Fatal(err)
+	}
+	c, ok := s.(Cached)
+	if !ok || c.Size != 10 || c.Quantum != unitX/1000 || c.cache == nil {
+		t.Fatalf("%#v", s)
+	}
+	c.property(unitX / 4)
+	if _, misses := c.Stats(); misses != 1 {
+		t.Error(misses)
+	}
+}
+
+func TestBufferedSequential(t *testing.T) {
+	s := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}
+	b := NewBuffered(s, unitX/8000, 64, 4)
+	defer b.Close()
+	for i := x(0); i < 800; i++ {
+		if b.property(i*b.Period) != s.property(i*b.Period) {
+			t.Fatal(i)
+		}
+	}
+	if hits, misses := b.Stats(); hits == 0 || hits+misses != 800 {
+		t.Error(hits, misses)
+	}
+}
+
+func TestBufferedOutOfWindow(t *testing.T) {
+	s := Sine{unitX / 400}
+	b := NewBuffered(s, unitX/8000, 16, 2)
+	defer b.Close()
+	for _, p := range []x{unitX / 3, -unitX / 8000 * 5, unitX * 7, unitX / 8000 * 3} {
+		if b.property(p) != s.property(p) {
+			t.Error(p)
+		}
+	}
+	// not a multiple of Period, so not counted.
+	b.property(unitX/8000 + unitX/8000/3)
+	if hits, misses := b.Stats(); hits+misses != 3 {
+		t.Error(hits, misses)
+	}
+}
+
+func TestBufferedSkipAhead(t *testing.T) {
+	s := Sine{unitX / 100}
+	b := NewBuffered(s, unitX/8000, 256, 2)
+	defer b.Close()
+	done := make(chan bool)
+	go func() {
+		for _, i := range []x{0, 3 * 256, 3*256 + 1, 9 * 256, 6 * 256} {
+			if b.property(i*b.Period) != s.property(i*b.Period) {
+				t.Error(i)
+			}
+			// let rendering get ahead, and wait.
+			time.Sleep(time.Millisecond * 10)
+		}
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("deadlocked")
+	}
+}
+
+func TestBufferedFinalized(t *testing.T) {
+	b := NewBuffered(Sine{unitX / 100}, unitX/8000, 256, 2)
+	b.property(0)
+	bb := b.buffer.blockBuffer
+	b = Buffered{}
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		bb.Lock()
+		closed := bb.closed
+		bb.Unlock()
+		if closed {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("not closed")
+}
+
+func TestBufferedLiteral(t *testing.T) {
+	b := Buffered{Signal: Sine{unitX}, Period: unitX / 8000, Block: 16, Ahead: 2}
+	if b.property(unitX/8) != (Sine{unitX}).property(unitX/8) {
+		t.Error(b.property(unitX / 8))
+	}
+	if hits, misses := b.Stats(); hits != 0 || misses != 0 {
+		t.Error(hits, misses)
+	}
+}
+
+func TestBufferedGOB(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGOB(&buf, NewBuffered(Sine{unitX}, unitX/8000, 32, 3)); err != nil {
+		t.Fatal(err)
+	}
+	var s Signal
+	if err := ReadGOB(&buf, &s); err != nil {
+		t.Fatal(err)
+	}
+	b, ok := s.(Buffered)
+	if !ok || b.buffer == nil || b.Block != 32 || b.Ahead != 3 || b.Period != unitX/8000 {
+		t.Fatalf("%#v", s)
+	}
+	b.Close()
+}
@@ -13,7 +13,7 @@ func init() {
 // Modulated is a PeriodicLimitedSignal, generated by multiplying together Signal(s).(Signal's can be PeriodicLimitedSignal's, so this can be hierarchical.)
 // Multiplication scales so that, unitY*unitY=unitY.
 // Modulated's MaxX() comes from the smallest contstituent MaxX(), (0 if none of the contained Signals are LimitedSignals.)
-// Modulated's Period() comes from its first member.
+// Modulated's Period() is the shortest common to its constituents, (see CommonPeriod), Constants and non-periodic LimitedSignals don't change it.
 // As with 'AND' logic, all sources have to be unitY (at a particular x) for Modulated to be unitY, whereas, ANY Signal at zero will generate a Modulated of zero.
 type Modulated []Signal
 
@@ -23,26 +23,19 @@ func (c Modulated) property(p x) (total y) {
 		l := s.property(p)
 		switch l {
 		case 0:
-			total = 0
-			break
+			return 0
 		case unitY:
 			continue
 		default:
-			//total = (total / Halfy) * (l / Halfy)*2
-			total = (total >> halfyBits) * (l >> halfyBits) * 2
+			total = multiplyY(total, l)
 		}
 	}
 	return
 }
 
-func (c Modulated) Period() (period x) {
-	// TODO could helpfully be the shortest period and any constituent.
-	if len(c) > 0 {
-		if s, ok := c[0].(PeriodicSignal); ok {
-			return s.Period()
-		}
-	}
-	return
+// the common period of the constituents, (0 if aperiodic, see PeriodOf.)
+func (c Modulated) Period() x {
+	return commonPeriod(c...)
 }
 
 // the smallest Max X of the constituents.
@@ -65,7 +58,7 @@ func NewModulated(c ...Signal) Modulated {
 
 // Composite is a PeriodicLimitedSignal, generated by adding together Signal(s). (PeriodicLimitedSignal's are Signal's so this can be hierarchical.)
 // Composite's MaxX() comes from the largest contstituent MaxX(), (0 if none of the contained Signals are LimitedSignals.)
-// Composite's Period() comes from its first member.
+// Composite's Period() is the shortest common to its constituents, (see CommonPeriod), Constants and non-periodic LimitedSignals don't change it.
 // As with 'OR' logic, all sources have to be zero (at a particular x) for Composite to be zero.
 type Composite []Signal
 
@@ -76,14 +69,9 @@ func (c Composite) property(p x) (total y) {
 	return
 }
 
-func (c Composite) Period() (period x) {
-	// TODO could be longest period muliple common to all constituents.
-	if len(c) > 0 {
-		if s, ok := c[0].(PeriodicSignal); ok {
-			return s.Period()
-		}
-	}
-	return
+// the common period of the constituents, (0 if aperiodic, see PeriodOf.)
+func (c Composite) Period() x {
+	return commonPeriod(c...)
 }
 
 // the largest Max X of the constituents.
@@ -105,6 +93,7 @@ func NewComposite(c ...Signal) Composite {
 }
 
 // Same as Composite except that Stacked scales down by the number of signals, making it impossible to exceed unitY.
+// Stacked's Period() is, as with Composite, the shortest common to its constituents.
 type Stacked []Signal
 
 func (c Stacked) property(p x) (total y) {
@@ -114,14 +103,9 @@ func (c Stacked) property(p x) (total y) {
 	return
 }
 
-func (c Stacked) Period() (period x) {
-	// TODO could be longest period muliple common to all constituents.
-	if len(c) > 0 {
-		if s, ok := c[0].(PeriodicSignal); ok {
-			return s.Period()
-		}
-	}
-	return
+// the common period of the constituents, (0 if aperiodic, see PeriodOf.)
+func (c Stacked) Period() x {
+	return commonPeriod(c...)
 }
 
 // the largest Max X of the constituents.
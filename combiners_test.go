@@ -3,6 +3,7 @@ package signals
 import (
 	"fmt"
 	"strings"
+	"testing"
 )
 
 func PrintGraph2(s Signal, start, end, step x) {
@@ -29,3 +30,26 @@ func ExampleCombinersSequenced() {
 	*/
 
 }
+
+// a Signal that counts the times its value is got.
+type countedSignal struct {
+	Signal
+	count *int
+}
+
+func (s countedSignal) property(p x) y {
+	*s.count++
+	return s.Signal.property(p)
+}
+
+// once a member is zero, the product is, so the rest aren't evaluated.
+func TestModulatedZero(t *testing.T) {
+	var n int
+	m := Modulated{Sine{unitX}, Pulse{unitX}, countedSignal{Sine{unitX / 3}, &n}}
+	if v := m.property(unitX * 2); v != 0 || n != 0 {
+		t.Error(v, n)
+	}
+	if v := m.property(unitX / 8); v != (Modulated{Sine{unitX}, Sine{unitX / 3}}).property(unitX/8) || n != 1 {
+		t.Error(v, n)
+	}
+}
@@ -0,0 +1,174 @@
+package signals
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"os"
+)
+
+// MaxError returns the largest absolute difference between the property values of two Signals, sampled from start, in steps, up to end.
+// differences too big to be represented are returned as unitY, as is no samples, (see samples), since then they aren't shown to be the same.
+func MaxError(s1, s2 Signal, start, end, step x) (max y) {
+	n, ok := samples(start, end, step)
+	if !ok {
+		return unitY
+	}
+	for i := 0; i < n; i++ {
+		p := start + x(i)*step
+		if d := difference(s1.property(p), s2.property(p)); d > max {
+			max = d
+		}
+	}
+	return
+}
+
+// the most samples compared.
+const maxSamples = math.MaxInt32
+
+// the number of samples, from start, in steps, before end, and false if there are none, (end not after start, or step not positive), or more than maxSamples.
+func samples(start, end, step x) (int, bool) {
+	if !(step > 0) || !(end > start) {
+		return 0, false
+	}
+	// end-start can overflow.
+	if r := float64(end-start) / float64(step); !(r > 0) || r > maxSamples {
+		return 0, false
+	}
+	n := sampleIndex(end-start, step)
+	if start+x(n)*step < end {
+		n++
+	}
+	return n, true
+}
+
+// absolute difference, saturating at unitY.
+func difference(a, b y) y {
+	if a < b {
+		a, b = b, a
+	}
+	if d := a - b; d >= 0 && d <= unitY {
+		return d
+	}
+	return unitY
+}
+
+// SNR returns the Signal to Noise Ratio, in dB, of a Signal compared to a reference Signal, sampled from start, in steps, up to end.
+// the 'noise' is the difference between them, so identical Signals return +Inf, no samples, (see samples), return NaN.
+func SNR(reference, s Signal, start, end, step x) float64 {
+	n, ok := samples(start, end, step)
+	if !ok {
+		return math.NaN()
+	}
+	r, v := sampled(reference, start, step, n), sampled(s, start, step, n)
+	var power, noise float64
+	for i := range r {
+		power += r[i] * r[i]
+		noise += (r[i] - v[i]) * (r[i] - v[i])
+	}
+	if noise == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(power/noise)
+}
+
+// SpectralDistance returns the log-spectral distance, in dB, between two Signals, sampled from start, in steps, up to end.
+// that is, the root mean square, over frequency, of the difference in their power spectra, so it is insensitive to phase and small shifts.
+// power more than 80dB below the peak is ignored.
+// no samples, (see samples), return NaN.
+func SpectralDistance(s1, s2 Signal, start, end, step x) float64 {
+	n, ok := samples(start, end, step)
+	if !ok {
+		return math.NaN()
+	}
+	size := powerOfTwo(n)
+	v1, v2 := sampled(s1, start, step, n), sampled(s2, start, step, n)
+	// Hann window, to reduce spectral leakage, which would otherwise depend on phase.
+	for i := range v1 {
+		w := .5 - .5*math.Cos(2*math.Pi*float64(i)/float64(n))
+		v1[i] *= w
+		v2[i] *= w
+	}
+	f1, f2 := padded(v1, size), padded(v2, size)
+	fft(f1, false)
+	fft(f2, false)
+	p1, p2 := make([]float64, size/2+1), make([]float64, size/2+1)
+	var peak float64
+	for i := range p1 {
+		p1[i], p2[i] = real(f1[i]*cmplx.Conj(f1[i])), real(f2[i]*cmplx.Conj(f2[i]))
+		peak = math.Max(peak, math.Max(p1[i], p2[i]))
+	}
+	if peak == 0 {
+		return 0
+	}
+	floor := peak * 1e-8
+	var sum float64
+	for i := range p1 {
+		d := 10 * (math.Log10(p1[i]+floor) - math.Log10(p2[i]+floor))
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(p1)))
+}
+
+// a Failer is anything that reports test failures, like testing.T and testing.B.
+type Failer interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertGolden reports to a Failer the error, if any, from CompareGolden.
+func AssertGolden(t Failer, pathTo string, tolerance y, sampleBytes uint8, sampleRate uint32, length x, ss ...Signal) {
+	if err := CompareGolden(pathTo, tolerance, sampleBytes, sampleRate, length, ss...); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+// CompareGolden Encodes Signals, (parameters as for Encode) and compares the result to a reference wav file at a path, returning an error if the format is different or any sample differs by more than tolerance.
+// if there is no file at the path, one is saved, from the Signals, to be the reference for subsequent comparisons. (delete it to update.)
+func CompareGolden(pathTo string, tolerance y, sampleBytes uint8, sampleRate uint32, length x, ss ...Signal) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, sampleBytes, sampleRate, length, ss...); err != nil {
+		return err
+	}
+	file, err := os.Open(pathTo)
+	if os.IsNotExist(err) {
+		file, err = os.Create(pathTo)
+		if err != nil {
+			return err
+		}
+		_, err = buf.WriteTo(file)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	golden, err := Decode(file)
+	if err != nil {
+		return err
+	}
+	current, err := Decode(&buf)
+	if err != nil {
+		return err
+	}
+	if len(golden) != len(current) {
+		return errors.New(fmt.Sprintf("%s: %d channels, golden has %d.", pathTo, len(current), len(golden)))
+	}
+	for c := range golden {
+		if golden[c].Period() != current[c].Period() {
+			return errors.New(fmt.Sprintf("%s: sample rate %d, golden has %d.", pathTo, int64(unitX/current[c].Period()), int64(unitX/golden[c].Period())))
+		}
+		if golden[c].MaxX() != current[c].MaxX() {
+			return errors.New(fmt.Sprintf("%s: length %v, golden has %v.", pathTo, current[c].MaxX(), golden[c].MaxX()))
+		}
+		if e := MaxError(golden[c], current[c], 0, current[c].MaxX()+current[c].Period(), current[c].Period()); e > tolerance {
+			return errors.New(fmt.Sprintf("%s: channel %d differs by %v, tolerance %v.", pathTo, c, e, tolerance))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package signals
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCompareMaxError(t *testing.T) {
+	if e := MaxError(Sine{unitX / 100}, Sine{unitX / 100}, 0, unitX, unitX/8000); e != 0 {
+		t.Error(e)
+	}
+	if e := MaxError(Sine{unitX / 100}, Modulated{Sine{unitX / 100}, NewConstant(-6)}, 0, unitX, unitX/8000); e < Y(.49) || e > Y(.51) {
+		t.Error(e)
+	}
+	if e := MaxError(Square{unitX}, Inverted{Square{unitX}}, 0, unitX, unitX/10); e != unitY {
+		t.Error("saturate", e)
+	}
+}
+
+func TestCompareSNR(t *testing.T) {
+	if r := SNR(Sine{unitX / 100}, Sine{unitX / 100}, 0, unitX, unitX/8000); !math.IsInf(r, 1) {
+		t.Error(r)
+	}
+	if r := SNR(Sine{unitX / 100}, Modulated{Sine{unitX / 100}, NewConstant(-6)}, 0, unitX, unitX/8000); r < 5.9 || r > 6.1 {
+		t.Error(r)
+	}
+	// silence isn't NaN.
+	for _, r := range []float64{
+		SNR(Sine{unitX / 100}, Constant{}, 0, unitX/10000, unitX/8000),
+		SNR(Constant{}, Constant{}, 0, unitX, unitX/8000),
+	} {
+		if !math.IsInf(r, 1) {
+			t.Error(r)
+		}
+	}
+	if r := SNR(Constant{}, Sine{unitX / 100}, 0, unitX, unitX/8000); !math.IsInf(r, -1) {
+		t.Error(r)
+	}
+}
+
+func TestCompareSpectralDistance(t *testing.T) {
+	if d := SpectralDistance(Sine{unitX / 100}, Shifted{Sine{unitX / 100}, unitX / 400}, 0, unitX, unitX/8000); d > .1 {
+		t.Error("phase", d)
+	}
+	if d := SpectralDistance(Sine{unitX / 100}, Sine{unitX / 300}, 0, unitX, unitX/8000); d < 2 {
+		t.Error("frequency", d)
+	}
+}
+
+func TestCompareNoSamples(t *testing.T) {
+	for _, r := range [][3]x{{0, 0, unitX / 8000}, {unitX, 0, unitX / 8000}, {0, unitX, 0}, {0, unitX, -unitX / 8000}, {0, 10 * unitX, unitX / 1000000000}} {
+		if e := MaxError(Sine{unitX / 100}, Sine{unitX / 100}, r[0], r[1], r[2]); e != unitY {
+			t.Error(r, e)
+		}
+		if d := SNR(Sine{unitX / 100}, Sine{unitX / 100}, r[0], r[1], r[2]); !math.IsNaN(d) {
+			t.Error(r, d)
+		}
+		if d := SpectralDistance(Sine{unitX / 100}, Sine{unitX / 100}, r[0], r[1], r[2]); !math.IsNaN(d) {
+			t.Error(r, d)
+		}
+	}
+	// samples up to, not including, end.
+	if n, ok := samples(0, unitX, unitX/8000); !ok || n != 8000 {
+		t.Error(n, ok)
+	}
+	if n, ok := samples(0, unitX/10000, unitX/8000); !ok || n != 1 {
+		t.Error(n, ok)
+	}
+}
+
+type failures []string
+
+func (f *failures) Errorf(format string, args ...interface{}) {
+	*f = append(*f, fmt.Sprintf(format, args...))
+}
+
+func TestCompareGolden(t *testing.T) {
+	s := Modulated{Looped{Pulse{unitX / 4}, unitX / 2}, Stacked{Sine{unitX / 450}, Sine{unitX / 350}}}
+	AssertGolden(t, "./test output/GoldenDialTone.wav", Y(.001), 2, 8000, unitX, s)
+	var f failures
+	AssertGolden(&f, "./test output/GoldenDialTone.wav", Y(.001), 2, 8000, unitX, Modulated{s, NewConstant(-1)})
+	if len(f) != 1 {
+		t.Error("level change not detected.")
+	}
+	f = nil
+	AssertGolden(&f, "./test output/GoldenDialTone.wav", Y(.001), 2, 8000, unitX/2, s)
+	if len(f) != 1 {
+		t.Error("length change not detected.")
+	}
+}
@@ -0,0 +1,95 @@
+package signals
+
+// ConcurrentSafe is implemented by Signals that can declare if their property values can be got from more than one goroutine at the same time.
+// Signals that contain other Signals are only safe if those are.
+// Signals that don't implement it are taken to be unsafe.
+type ConcurrentSafe interface {
+	Signal
+	ConcurrentSafe() bool
+}
+
+// IsConcurrentSafe returns if a Signal declares itself ConcurrentSafe.
+func IsConcurrentSafe(s Signal) bool {
+	cs, ok := s.(ConcurrentSafe)
+	return ok && cs.ConcurrentSafe()
+}
+
+func allConcurrentSafe(ss ...Signal) bool {
+	for _, s := range ss {
+		if !IsConcurrentSafe(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// sources, with only immutable state, (Noise locks its shared generator.)
+
+func (Constant) ConcurrentSafe() bool     { return true }
+func (Sine) ConcurrentSafe() bool         { return true }
+func (Sinc) ConcurrentSafe() bool         { return true }
+func (Gauss) ConcurrentSafe() bool        { return true }
+func (Pulse) ConcurrentSafe() bool        { return true }
+func (Square) ConcurrentSafe() bool       { return true }
+func (RampUp) ConcurrentSafe() bool       { return true }
+func (RampDown) ConcurrentSafe() bool     { return true }
+func (Heavyside) ConcurrentSafe() bool    { return true }
+func (Sigmoid) ConcurrentSafe() bool      { return true }
+func (PulsePattern) ConcurrentSafe() bool { return true }
+func (ADSREnvelope) ConcurrentSafe() bool { return true }
+func (Noise) ConcurrentSafe() bool        { return true }
+func (PCM) ConcurrentSafe() bool          { return true }
+
+// modifiers and combiners, safe if what they contain is. (Cached, Segmented and Triggered lock their state.)
+
+func (s Shifted) ConcurrentSafe() bool       { return IsConcurrentSafe(s.Signal) }
+func (s Offset) ConcurrentSafe() bool        { return IsConcurrentSafe(s.LimitedSignal) }
+func (s Compressed) ConcurrentSafe() bool    { return IsConcurrentSafe(s.Signal) }
+func (s Looped) ConcurrentSafe() bool        { return IsConcurrentSafe(s.Signal) }
+func (s Repeated) ConcurrentSafe() bool      { return IsConcurrentSafe(s.PeriodicSignal) }
+func (s Inverted) ConcurrentSafe() bool      { return IsConcurrentSafe(s.Signal) }
+func (s Reversed) ConcurrentSafe() bool      { return IsConcurrentSafe(s.Signal) }
+func (s Reflected) ConcurrentSafe() bool     { return IsConcurrentSafe(s.Signal) }
+func (s Interpolated) ConcurrentSafe() bool  { return IsConcurrentSafe(s.PeriodicSignal) }
+func (s RateModulated) ConcurrentSafe() bool { return allConcurrentSafe(s.Signal, s.Modulation) }
+func (s Cached) ConcurrentSafe() bool        { return IsConcurrentSafe(s.Signal) }
+func (s Buffered) ConcurrentSafe() bool      { return IsConcurrentSafe(s.Signal) }
+func (s Segmented) ConcurrentSafe() bool     { return IsConcurrentSafe(s.Signal) }
+func (s Triggered) ConcurrentSafe() bool     { return IsConcurrentSafe(s.Signal) }
+func (s FadeIn) ConcurrentSafe() bool        { return IsConcurrentSafe(s.Signal) }
+func (s FadeOut) ConcurrentSafe() bool       { return IsConcurrentSafe(s.LimitedSignal) }
+func (c CrossfadeSequence) ConcurrentSafe() bool {
+	return allConcurrentSafe(PromoteToSignals(c.LimitedSignals)...)
+}
+func (c IndexedSequence) ConcurrentSafe() bool {
+	return allConcurrentSafe(PromoteToSignals(c.LimitedSignals())...)
+}
+func (e Event) ConcurrentSafe() bool {
+	return IsConcurrentSafe(e.LimitedSignal) && (e.Envelope == nil || IsConcurrentSafe(e.Envelope))
+}
+func (t Timeline) ConcurrentSafe() bool {
+	for _, e := range t.Events() {
+		if !e.ConcurrentSafe() {
+			return false
+		}
+	}
+	return true
+}
+func (c Modulated) ConcurrentSafe() bool { return allConcurrentSafe(c...) }
+func (c Composite) ConcurrentSafe() bool { return allConcurrentSafe(c...) }
+func (c Stacked) ConcurrentSafe() bool   { return allConcurrentSafe(c...) }
+
+func (c Sequenced) ConcurrentSafe() bool {
+	for _, s := range c {
+		if !IsConcurrentSafe(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// unsafe.
+
+// a Wave, and a Recorder, are locked, but a Wave only buffers recent data, and a Recorder's grows, and is dropped, as it's written, so their values depend on the order, and time, they are got in.
+func (*Wave) ConcurrentSafe() bool     { return false }
+func (*Recorder) ConcurrentSafe() bool { return false }
@@ -0,0 +1,76 @@
+package signals
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSafe(t *testing.T) {
+	for _, c := range []struct {
+		Signal
+		safe bool
+	}{
+		{Sine{unitX}, true},
+		{NewPCM16bit(8000, nil), true},
+		{Modulated{Sine{unitX}, NewConstant(-6)}, true},
+		{Offset{Pulse{unitX}, unitX}, true},
+		{NewSegmented(Sine{unitX}, unitX/10), true},
+		{Stacked{Sine{unitX}, NewTriggered(Sine{unitX}, 0, true, unitX/100, unitX)}, true},
+		{Inverted{NewCached(Sine{unitX}, 0, 0)}, true},
+		{Inverted{NewCached(NewTriggered(&Wave{URL: testDataURL}, 0, true, unitX/100, unitX), 0, 0)}, false},
+		{Stacked{Sine{unitX}, &Wave{URL: testDataURL}}, false},
+		{NewRecorder(8000, 2, 1), false},
+		{unknownSignal{}, false},
+	} {
+		if IsConcurrentSafe(c.Signal) != c.safe {
+			t.Errorf("%T %v", c.Signal, !c.safe)
+		}
+	}
+}
+
+// property values got concurrently are the same as got serially.
+// run with -race.
+func TestConcurrentSafeRace(t *testing.T) {
+	const goroutines, samples = 8, 500
+	for _, s := range builtins() {
+		if !IsConcurrentSafe(s) {
+			continue
+		}
+		serial := make([]y, samples)
+		for i := range serial {
+			serial[i] = s.property(x(i) * unitX / 1000)
+		}
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int, s Signal) {
+				defer wg.Done()
+				for i := range serial {
+					j := (i*(g+1) + g) % samples // different orders
+					if v := s.property(x(j) * unitX / 1000); v != serial[j] {
+						t.Errorf("%T at %v: %v != %v", s, x(j)*unitX/1000, v, serial[j])
+						return
+					}
+				}
+			}(g, s)
+		}
+		wg.Wait()
+	}
+}
+
+// unsafe Signals still can't be broken by being used concurrently, when they lock their state.
+func TestWaveRace(t *testing.T) {
+	s := &Wave{URL: testDataURL}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				s.property(x(i*4+g) * unitX / 44100)
+				s.MaxX()
+			}
+		}(g)
+	}
+	wg.Wait()
+}
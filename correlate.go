@@ -0,0 +1,80 @@
+package signals
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// CrossCorrelation returns the shift, from minShift to maxShift, that best aligns s2 with s1, so that Shifted{s2, shift}, or Offset{s2, shift}, most closely resembles s1.
+// also returned is the normalised correlation at that shift, 1 for an identical shape, (0 if either Signal has no energy.)
+// both Signals are sampled at sampleRate, from zero to the larger MaxX(), so the shift found has a resolution of one sample period.
+// a sampleRate of zero is an error.
+func CrossCorrelation(s1, s2 LimitedSignal, sampleRate uint32, minShift, maxShift x) (shift x, correlation float64, err error) {
+	return correlate(s1, s2, sampleRate, minShift, maxShift, false)
+}
+
+// GCCPHAT is the same as CrossCorrelation except that it uses the Generalized Cross Correlation with PHAse Transform weighting.
+// only the phase of the constituent frequencies is compared, giving a much sharper peak, less effected by the spectral content, and so better for reverberant recordings and finding echoes.
+// the returned peak is between 0 and 1, 1 for a perfect pure delay.
+func GCCPHAT(s1, s2 LimitedSignal, sampleRate uint32, minShift, maxShift x) (shift x, peak float64, err error) {
+	return correlate(s1, s2, sampleRate, minShift, maxShift, true)
+}
+
+func correlate(s1, s2 LimitedSignal, sampleRate uint32, minShift, maxShift x, phaseTransform bool) (shift x, peak float64, err error) {
+	if sampleRate == 0 {
+		return 0, 0, errors.New("Unsupported sample rate (0).")
+	}
+	samplePeriod := X(1 / float32(sampleRate))
+	length := s1.MaxX()
+	if l := s2.MaxX(); l > length {
+		length = l
+	}
+	samples := sampleIndex(length, samplePeriod) + 1
+	v1, v2 := sampled(s1, 0, samplePeriod, samples), sampled(s2, 0, samplePeriod, samples)
+	// padded to, at least, twice the length so the circular correlation doesn't wrap round.
+	size := powerOfTwo(samples * 2)
+	f1, f2 := padded(v1, size), padded(v2, size)
+	fft(f1, false)
+	fft(f2, false)
+	for i := range f1 {
+		f1[i] *= cmplx.Conj(f2[i])
+		if phaseTransform {
+			if m := cmplx.Abs(f1[i]); m > 0 {
+				f1[i] /= complex(m, 0)
+			}
+		}
+	}
+	fft(f1, true)
+	scale := 1 / float64(size)
+	if !phaseTransform {
+		var e1, e2 float64
+		for i := range v1 {
+			e1 += v1[i] * v1[i]
+			e2 += v2[i] * v2[i]
+		}
+		if e1 == 0 || e2 == 0 {
+			return minShift, 0, nil
+		}
+		scale /= math.Sqrt(e1 * e2)
+	}
+	// f1 now holds the correlation for each lag, negative lags wrapped round to the end.
+	minLag, maxLag := sampleIndex(minShift, samplePeriod), sampleIndex(maxShift, samplePeriod)
+	if minLag <= -samples {
+		minLag = 1 - samples
+	}
+	if maxLag >= samples {
+		maxLag = samples - 1
+	}
+	peak = math.Inf(-1)
+	for lag := minLag; lag <= maxLag; lag++ {
+		if c := real(f1[(lag+size)%size]) * scale; c > peak {
+			peak = c
+			shift = x(lag) * samplePeriod
+		}
+	}
+	if math.IsInf(peak, -1) {
+		return minShift, 0, nil
+	}
+	return
+}
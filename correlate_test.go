@@ -0,0 +1,58 @@
+package signals
+
+import (
+	"testing"
+)
+
+func TestCorrelateCrossCorrelation(t *testing.T) {
+	s := Modulated{Pulse{unitX / 5}, RampUp{unitX / 5}}
+	shift, c, err := CrossCorrelation(Offset{s, unitX / 4}, s, 8000, -unitX, unitX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shift != unitX/4 {
+		t.Error("shift", shift, unitX/4)
+	}
+	if c < .99 {
+		t.Error("correlation", c)
+	}
+	shift, _, _ = CrossCorrelation(s, Offset{s, unitX / 4}, 8000, -unitX, unitX)
+	if shift != -unitX/4 {
+		t.Error("negative shift", shift, -unitX/4)
+	}
+	// limited search range
+	shift, _, _ = CrossCorrelation(Offset{s, unitX / 4}, s, 8000, 0, unitX/8)
+	if shift != unitX/8 {
+		t.Error("limited shift", shift, unitX/8)
+	}
+}
+
+func TestCorrelateCrossCorrelationSilent(t *testing.T) {
+	_, c, _ := CrossCorrelation(Pulse{unitX}, Modulated{Pulse{unitX}, Constant{0}}, 8000, -unitX, unitX)
+	if c != 0 {
+		t.Error(c)
+	}
+}
+
+func TestCorrelateGCCPHATEcho(t *testing.T) {
+	s := Modulated{Pulse{unitX / 20}, Stacked{Sine{unitX / 450}, Sine{unitX / 350}}}
+	echoed := Composite{s, Offset{Modulated{s, NewConstant(-6)}, unitX * 3 / 10}}
+	shift, p, _ := GCCPHAT(echoed, s, 8000, -unitX, unitX)
+	if shift != 0 {
+		t.Error("direct", shift, p)
+	}
+	// exclude the direct sound
+	shift, p, _ = GCCPHAT(echoed, s, 8000, unitX/10, unitX)
+	if shift != unitX*3/10 {
+		t.Error("echo", shift, p)
+	}
+}
+
+func TestCorrelateZeroRate(t *testing.T) {
+	if _, _, err := CrossCorrelation(Pulse{unitX}, Pulse{unitX}, 0, -unitX, unitX); err == nil {
+		t.Error("zero rate.")
+	}
+	if _, _, err := GCCPHAT(Pulse{unitX}, Pulse{unitX}, 0, -unitX, unitX); err == nil {
+		t.Error("zero rate.")
+	}
+}
@@ -0,0 +1,124 @@
+package signals
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// longest parameter text in a diagram label, longer ones, like long bit patterns, are cut short.
+const maxLabelText = 40
+
+// WriteDOT writes a GraphViz DOT diagram of a composition, a box for each Signal, labelled with its type and parameters, and arrows to the Signals it's made from.
+// PCM Signals are labelled with their sample rate and duration, rather than data.
+func WriteDOT(w io.Writer, s Signal) error {
+	d := diagram{}
+	Walk(&d, s)
+	var b bytes.Buffer
+	b.WriteString("digraph Signal {\n\tnode [shape=box];\n")
+	for i, l := range d.labels {
+		fmt.Fprintf(&b, "\tn%d [label=\"%s\"];\n", i, strings.Join(escapeAll(l, dotEscaper), "\\n"))
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(&b, "\tn%d -> n%d;\n", e[0], e[1])
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteMermaid writes a Mermaid flowchart of a composition, labelled as WriteDOT does.
+func WriteMermaid(w io.Writer, s Signal) error {
+	d := diagram{}
+	Walk(&d, s)
+	var b bytes.Buffer
+	b.WriteString("flowchart TD\n")
+	for i, l := range d.labels {
+		fmt.Fprintf(&b, "\tn%d[\"%s\"]\n", i, strings.Join(escapeAll(l, mermaidEscaper), "<br/>"))
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(&b, "\tn%d --> n%d\n", e[0], e[1])
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+func escapeAll(lines []string, r *strings.Replacer) []string {
+	escaped := make([]string, len(lines))
+	for i := range lines {
+		escaped[i] = r.Replace(lines[i])
+	}
+	return escaped
+}
+
+// a diagram's node labels, numbered in the order Walk visits them, and the edges between them, from a Signal to the Signals it's made from.
+type diagram struct {
+	labels  [][]string
+	edges   [][2]int
+	parents []int
+}
+
+func (d *diagram) Visit(n *Node) Visitor {
+	if n == nil {
+		d.parents = d.parents[:len(d.parents)-1]
+		return nil
+	}
+	id := len(d.labels)
+	d.labels = append(d.labels, label(n))
+	if len(d.parents) > 0 {
+		d.edges = append(d.edges, [2]int{d.parents[len(d.parents)-1], id})
+	}
+	d.parents = append(d.parents, id)
+	return d
+}
+
+// the lines of a Node's label, its type then a line per parameter.
+// sample data is shown as its duration, (samples times the sample period), and the sample period as a rate.
+// other x's are shown as a time, or, for periods, a frequency, with their units.
+func label(n *Node) []string {
+	sampled := false
+	for _, p := range n.Parameters {
+		if _, ok := p.Value.([]byte); ok {
+			sampled = true
+		}
+	}
+	lines := []string{n.Type}
+	for _, p := range n.Parameters {
+		switch {
+		case sampled && p.Name == "Period":
+			lines = append(lines, "rate "+p.Value.(x).Frequency())
+		case sampled:
+			if _, ok := p.Value.([]byte); ok {
+				lines = append(lines, "duration "+(n.MaxX+n.Period).Time())
+			}
+		default:
+			text := p.Text
+			if v, ok := p.Value.(x); ok {
+				if isPeriod(n.Type, p.Name) {
+					text = v.Frequency()
+				} else {
+					text = v.Time()
+				}
+			}
+			if len([]rune(text)) > maxLabelText {
+				text = string([]rune(text)[:maxLabelText]) + "..."
+			}
+			lines = append(lines, p.Name+" "+text)
+		}
+	}
+	return lines
+}
+
+// if a built-in Signal type's parameter is a period.
+func isPeriod(typeName, name string) bool {
+	for _, p := range signalTypes[typeName].parameters {
+		if p.name == name {
+			return p.kind == periodKind
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package signals
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+)
+
+func ExampleWriteDOT() {
+	ringing := Modulated{Looped{Pulse{unitX * 2}, unitX * 6}, Stacked{Sine{Hz(440)}, Sine{Hz(480)}}, NewConstant(-6)}
+	WriteDOT(os.Stdout, ringing)
+	// Output:
+	// digraph Signal {
+	// 	node [shape=box];
+	// 	n0 [label="Modulated"];
+	// 	n1 [label="Looped\nLoop 6s"];
+	// 	n2 [label="Pulse\nWidth 2s"];
+	// 	n3 [label="Stacked"];
+	// 	n4 [label="Sine\nCycle 440Hz"];
+	// 	n5 [label="Sine\nCycle 480Hz"];
+	// 	n6 [label="Constant\nConstant -6dB"];
+	// 	n0 -> n1;
+	// 	n1 -> n2;
+	// 	n0 -> n3;
+	// 	n3 -> n4;
+	// 	n3 -> n5;
+	// 	n0 -> n6;
+	// }
+}
+
+func ExampleWriteMermaid() {
+	WriteMermaid(os.Stdout, Offset{NewPCM16bit(8000, make([]byte, 16000)), Ms(250)})
+	// Output:
+	// flowchart TD
+	// 	n0["Offset<br/>Offset 250ms"]
+	// 	n1["PCM16bit<br/>rate 8000Hz<br/>duration 1s"]
+	// 	n0 --> n1
+}
+
+func TestDiagramLabels(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteDOT(&b, &Wave{URL: `file:///a "quoted" name.wav`}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `n0 [label="Wave\nURL \"file:///a \\\"quoted\\\" name.wav\""];`) {
+		t.Error(b.String())
+	}
+	b.Reset()
+	var pattern big.Int
+	pattern.SetString(strings.Repeat("10", 30), 2)
+	if err := WriteMermaid(&b, PulsePattern{pattern, Ms(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `n0["PulsePattern<br/>BitPattern 0b10101010101010101010101010101010101010...<br/>PulseWidth 1ms"]`) {
+		t.Error(b.String())
+	}
+	b.Reset()
+	if err := WriteMermaid(&b, Looped{Sine{Ms(3)}, Ms(750)}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `n0["Looped<br/>Loop 750ms"]`) || !strings.Contains(b.String(), `n1["Sine<br/>Cycle 333.333Hz"]`) {
+		t.Error(b.String())
+	}
+}
@@ -20,6 +20,10 @@ the 'property', a value between limits, +unitY and -unitY.
 
 (the underlying types of x and y are kept hidden to enable simple generation of optimised packages with different ranges/precisions.)
 
+x's and y's can be made from, and returned as, physical units, see Hz, Ms, Seconds, DBFS, Percent and Volts.
+
+(building with '-tags float64' makes them float64's, x in seconds, for very small or very large x's, see float64.go.)
+
 
 	Signal - Interface
 
@@ -30,8 +34,19 @@ fundamentally procedural, calculated as needed, so that any 'x' value returns a
 changes to parameters effect returned values from any other Signals composed from them.
 
 saved/loaded, lossily, as PCM data. (PCM data can be Waveform Audio File Format ,.wav file.)
+encoded using all CPUs, if ConcurrentSafe, see EncodeParallel. (all built-in types are, except Wave and Recorder, if what they contain is.)
+streamed, without a length, see EncodeStream, or read as raw PCM data, see SampleReader.
+recorded, from PCM data written incrementally, see Recorder.
+rendered, in memory, to PCM Signals, so expensive parts of a composition are only evaluated once, see Render and Freeze.
 
 saved/loaded from a go code binary (.gob) file, (and signals can stream data, including gob files.) making for a basic interpreted signal language.
+or in a versioned container, with metadata and a checksum, that doesn't depend on Go type names, see WriteGOBContainer and ReadGOBContainer.
+
+written/read as text, nested type names with bracketed parameters, (see ParseSignal and FormatSignal) for example: Modulated(Sine(400Hz), Constant(-6dB))
+
+written/read as JSON, (also YAML compatible) see WriteJSON, ReadJSON and JSONSignal.
+
+written as Go source, to compile in, see GoSource and WriteGoSource.
 
 
 	LimitedSignal - Interface
@@ -49,6 +64,8 @@ or when required any fundamental wavelength
 
 or the sample spacing for one of the PCM Signal types.
 
+Signals made from others have the shortest Period common to them, or zero if they're aperiodic, PeriodOf returns which explicitly.
+
 
 	PeriodicLimitedSignal - Interface
 
@@ -0,0 +1,81 @@
+package signals
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// number of samples, per channel, in the chunks rendered concurrently.
+const parallelChunkSamples = 4096
+
+// EncodeParallel encodes Signals as Encode, but renders chunks of samples concurrently, using all CPUs, writing them in order.
+// Signals that aren't ConcurrentSafe are encoded by Encode.
+func EncodeParallel(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...Signal) error {
+	switch sampleBytes {
+	case 1, 2, 3, 4, 6, 8:
+	default:
+		return errors.New(fmt.Sprintf("Unsupported sample bytes (%d).", sampleBytes))
+	}
+	if !allConcurrentSafe(ss...) {
+		return Encode(w, sampleBytes, sampleRate, length, ss...)
+	}
+	samplePeriod := X(1 / float32(sampleRate))
+	samples := int64(sampleIndex(length, samplePeriod)) + 1
+	frame := int(sampleBytes) * len(ss)
+	buf := bufio.NewWriter(w)
+	if err := writeWaveHeader(buf, sampleBytes, sampleRate, uint16(len(ss)), uint32(samples*int64(frame))); err != nil {
+		return err
+	}
+
+	workers := runtime.NumCPU()
+	free := make(chan []byte, workers+1)
+	for i := 0; i < cap(free); i++ {
+		free <- make([]byte, parallelChunkSamples*frame)
+	}
+	// rendered chunks, in order, as they are started.
+	pending := make(chan chan []byte, workers)
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		defer close(pending)
+		for start := int64(0); start < samples; start += parallelChunkSamples {
+			var b []byte
+			select {
+			case b = <-free:
+			case <-quit:
+				return
+			}
+			n := int64(parallelChunkSamples)
+			if samples-start < n {
+				n = samples - start
+			}
+			done := make(chan []byte, 1)
+			select {
+			case pending <- done:
+			case <-quit:
+				return
+			}
+			go func(b []byte, start, n int64) {
+				for i := int64(0); i < n; i++ {
+					p := x(start+i) * samplePeriod
+					for c, s := range ss {
+						offset := int(i)*frame + c*int(sampleBytes)
+						encodeSample(b[offset:offset+int(sampleBytes)], s.property(p))
+					}
+				}
+				done <- b[:int(n)*frame]
+			}(b, start, n)
+		}
+	}()
+	for done := range pending {
+		b := <-done
+		if _, err := buf.Write(b); err != nil {
+			return err
+		}
+		free <- b[:cap(b)]
+	}
+	return buf.Flush()
+}
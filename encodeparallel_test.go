@@ -0,0 +1,40 @@
+package signals
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestEncodeParallel(t *testing.T) {
+	s1 := Modulated{Stacked{Sine{unitX / 350}, Sine{unitX / 440}}, NewADSREnvelope(unitX/10, unitX/10, unitX/2, unitY/2, unitX/5)}
+	s2 := Modulated{Sine{unitX / 300}, Pulse{unitX / 2}}
+	for _, ss := range [][]Signal{{s1}, {s1, s2}, {NewSegmented(s1, unitX/1000)}} {
+		var serial, parallel bytes.Buffer
+		if err := Encode(&serial, 2, 44100, s1.MaxX(), ss...); err != nil {
+			t.Fatal(err)
+		}
+		if err := EncodeParallel(&parallel, 2, 44100, s1.MaxX(), ss...); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(serial.Bytes(), parallel.Bytes()) {
+			t.Errorf("%d channels differ.", len(ss))
+		}
+	}
+}
+
+func TestEncodeParallelSampleBytes(t *testing.T) {
+	for _, s := range []Signal{Sine{unitX / 100}, &Wave{}} {
+		var b bytes.Buffer
+		if err := EncodeParallel(&b, 5, 8000, unitX/10, s); err == nil || b.Len() != 0 {
+			t.Errorf("%T %v %d", s, err, b.Len())
+		}
+	}
+}
+
+func BenchmarkEncodeParallel(b *testing.B) {
+	s := Modulated{Stacked{Sine{unitX / 350}, Sine{unitX / 440}}, NewConstant(-6)}
+	for i := 0; i < b.N; i++ {
+		EncodeParallel(ioutil.Discard, 2, 44100, unitX, s)
+	}
+}
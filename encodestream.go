@@ -0,0 +1,159 @@
+package signals
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// number of samples, per channel, encoded between checks for cancellation.
+const streamBlockSamples = 1024
+
+// EncodeStream encodes Signals as PCM data, interleaved, as Encode, but without needing a length, continuing until the context is cancelled, or, if all the Signals are LimitedSignals, their end.
+// if w is a working io.WriteSeeker, a Riff wave container is written, with placeholder sizes, which are patched when finished, otherwise headerless (raw) PCM is written, (8bit unsigned, otherwise signed little-endian) suitable for, say, "aplay -f S16_LE -r 44100".
+// cancellation isn't an error, the stream is finished and nil returned, (use ctx.Err() to tell.)
+func EncodeStream(ctx context.Context, w io.Writer, sampleBytes uint8, sampleRate uint32, ss ...Signal) error {
+	if len(ss) == 0 {
+		return errors.New("no Signals to encode.")
+	}
+	switch sampleBytes {
+	case 1, 2, 3, 4, 6, 8:
+	default:
+		return errors.New(fmt.Sprintf("Unsupported sample bytes (%d).", sampleBytes))
+	}
+	if sampleRate == 0 {
+		return errors.New("Unsupported sample rate (0).")
+	}
+	samplePeriod := X(1 / float32(sampleRate))
+	samples := uint64(math.MaxUint64)
+	if end, limited := maxX(ss); limited {
+		samples = uint64(sampleIndex(end, samplePeriod)) + 1
+	}
+
+	ws, seekable := w.(io.WriteSeeker)
+	var start int64
+	if seekable {
+		var err error
+		if start, err = ws.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false // a pipe or terminal
+		}
+	}
+	if seekable {
+		if err := writeWaveHeader(w, sampleBytes, sampleRate, uint16(len(ss)), math.MaxUint32); err != nil {
+			return err
+		}
+	}
+
+	frame := int(sampleBytes) * len(ss)
+	block := make([]byte, streamBlockSamples*frame)
+	var written uint64
+	for written < samples {
+		select {
+		case <-ctx.Done():
+			samples = written
+			continue
+		default:
+		}
+		n := uint64(streamBlockSamples)
+		if samples-written < n {
+			n = samples - written
+		}
+		for i := uint64(0); i < n; i++ {
+			p := x(written+i) * samplePeriod
+			for c, s := range ss {
+				offset := int(i)*frame + c*int(sampleBytes)
+				encodeSample(block[offset:offset+int(sampleBytes)], s.property(p))
+			}
+		}
+		if _, err := w.Write(block[:int(n)*frame]); err != nil {
+			return err
+		}
+		written += n
+	}
+
+	if !seekable {
+		return nil
+	}
+	// patch sizes, limited to what a Riff header can hold.
+	dataLen := written * uint64(frame)
+	if dataLen > math.MaxUint32-36 {
+		dataLen = math.MaxUint32 - 36
+	}
+	if _, err := ws.Seek(start+4, io.SeekStart); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(dataLen+36)); err != nil {
+		return err
+	}
+	if _, err := ws.Seek(start+40, io.SeekStart); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(dataLen)); err != nil {
+		return err
+	}
+	_, err := ws.Seek(0, io.SeekEnd)
+	return err
+}
+
+// the largest MaxX of some Signals, if they are all LimitedSignals. (a MaxX of zero or less is taken as not being limited, as with a Modulated of unlimited Signals.)
+func maxX(ss []Signal) (end x, limited bool) {
+	for _, s := range ss {
+		ls, ok := s.(LimitedSignal)
+		if !ok || ls.MaxX() <= 0 {
+			return 0, false
+		}
+		if ls.MaxX() > end {
+			end = ls.MaxX()
+		}
+	}
+	return end, len(ss) > 0
+}
+
+// write a Riff wave header, 44 bytes, for PCM data of the given length in bytes.
+func writeWaveHeader(w io.Writer, sampleBytes uint8, sampleRate uint32, channels uint16, dataLen uint32) error {
+	riffLen := dataLen + 36
+	if dataLen > math.MaxUint32-36 {
+		riffLen = math.MaxUint32
+	}
+	if err := binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'R', 'I', 'F', 'F'}, riffLen}); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{'W', 'A', 'V', 'E'}); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'f', 'm', 't', ' '}, 16}); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, formatChunk{
+		Code:        1,
+		Channels:    channels,
+		SampleRate:  sampleRate,
+		ByteRate:    sampleRate * uint32(sampleBytes) * uint32(channels),
+		SampleBytes: uint16(sampleBytes) * channels,
+		Bits:        uint16(8 * sampleBytes),
+	}); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'d', 'a', 't', 'a'}, dataLen})
+}
+
+// encode a value into a sample, of len(b) bytes, in the same format as Riff wave PCM data.
+func encodeSample(b []byte, v y) {
+	switch len(b) {
+	case 1:
+		b[0] = encodePCM8bit(v)
+	case 2:
+		b[0], b[1] = encodePCM16bit(v)
+	case 3:
+		b[0], b[1], b[2] = encodePCM24bit(v)
+	case 4:
+		b[0], b[1], b[2], b[3] = encodePCM32bit(v)
+	case 6:
+		b[0], b[1], b[2], b[3], b[4], b[5] = encodePCM48bit(v)
+	case 8:
+		b[0], b[1], b[2], b[3], b[4], b[5], b[6], b[7] = encodePCM64bit(v)
+	}
+}
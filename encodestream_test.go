@@ -0,0 +1,93 @@
+package signals
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestEncodeStreamLimited(t *testing.T) {
+	s := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}
+	file, err := os.Create("./test output/EncodeStream.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := EncodeStream(context.Background(), file, 2, 8000, s); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	streamed, err := ioutil.ReadFile("./test output/EncodeStream.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded bytes.Buffer
+	if err := Encode(&encoded, 2, 8000, s.MaxX(), s); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(streamed, encoded.Bytes()) {
+		t.Errorf("streamed %d bytes, encoded %d bytes", len(streamed), encoded.Len())
+	}
+}
+
+func TestEncodeStreamZeroRate(t *testing.T) {
+	var b bytes.Buffer
+	if err := EncodeStream(context.Background(), &b, 2, 0, Sine{unitX / 400}); err == nil || b.Len() != 0 {
+		t.Error(err, b.Len())
+	}
+}
+
+// cancels a context after a number of bytes have been written.
+type cancellingWriter struct {
+	bytes.Buffer
+	limit  int
+	cancel context.CancelFunc
+}
+
+func (w *cancellingWriter) Write(b []byte) (int, error) {
+	if w.Len() >= w.limit {
+		w.cancel()
+	}
+	return w.Buffer.Write(b)
+}
+
+func TestEncodeStreamUnlimitedRaw(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := cancellingWriter{limit: 10000, cancel: cancel}
+	if err := EncodeStream(ctx, &w, 2, 8000, Sine{unitX / 400}, Sine{unitX / 300}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Len() < 10000 || w.Len()%(streamBlockSamples*4) != 0 {
+		t.Error(w.Len())
+	}
+	if bytes.HasPrefix(w.Bytes(), []byte("RIFF")) {
+		t.Error("header written.")
+	}
+	var encoded bytes.Buffer
+	Encode(&encoded, 2, 8000, unitX/10, Sine{unitX / 400}, Sine{unitX / 300})
+	if !bytes.Equal(w.Bytes()[:400], encoded.Bytes()[44:444]) {
+		t.Error("raw data differs.")
+	}
+}
+
+func TestEncodeStreamCancelledWave(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	file, err := os.Create("./test output/EncodeStreamCancelled.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := EncodeStream(ctx, file, 1, 8000, Sine{unitX / 400}); err != nil {
+		t.Fatal(err)
+	}
+	file.Seek(0, 0)
+	channels, err := Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 1 || len(channels[0].(PCM8bit).Data) != 0 {
+		t.Error(channels)
+	}
+}
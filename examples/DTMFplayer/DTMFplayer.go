@@ -10,14 +10,15 @@ import (
 	"bufio"
 	"flag"
 	"io"
+	"io/ioutil"
 	"os"
-	"bytes"
 )
 
 import . "github.com/splace/signals"
 
-var length = X(.07)
-var gap = X(.08)
+// the durations, as Pulses, to limit Signals with.
+var length = Pulse{Ms(70)}
+var gap = Pulse{Ms(80)}
 
 func main() {
 	help := flag.Bool("help", false, "display help/usage.")
@@ -28,27 +29,32 @@ func main() {
 		flag.PrintDefaults()
 		os.Exit(0)
 	}
+	// raw PCM data, 16bit, for a Signal, limited to a duration.
+	tone := func(s Signal, d Pulse) []byte {
+		data, _ := ioutil.ReadAll(NewSampleReader(SampleFormat{Rate: uint32(sampleRate), Bytes: 2}, Modulated{s, d}))
+		return data
+	}
 	// cache the raw PCM data for each tone. (helps efficiency if a lot of repeat tones.)  
-	var Tones = map[rune]PCM{
-		'0': NewPCMSignal(Stacked{Sine{X(1.0 / 941)}, Sine{X(1.0 / 1336)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'1': NewPCMSignal(Stacked{Sine{X(1.0 / 697)}, Sine{X(1.0 / 1209)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'2': NewPCMSignal(Stacked{Sine{X(1.0 / 697)}, Sine{X(1.0 / 1336)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'3': NewPCMSignal(Stacked{Sine{X(1.0 / 697)}, Sine{X(1.0 / 1477)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'4': NewPCMSignal(Stacked{Sine{X(1.0 / 770)}, Sine{X(1.0 / 1209)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'5': NewPCMSignal(Stacked{Sine{X(1.0 / 770)}, Sine{X(1.0 / 1336)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'6': NewPCMSignal(Stacked{Sine{X(1.0 / 770)}, Sine{X(1.0 / 1477)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'7': NewPCMSignal(Stacked{Sine{X(1.0 / 852)}, Sine{X(1.0 / 1209)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'8': NewPCMSignal(Stacked{Sine{X(1.0 / 852)}, Sine{X(1.0 / 1336)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'9': NewPCMSignal(Stacked{Sine{X(1.0 / 852)}, Sine{X(1.0 / 1477)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'A': NewPCMSignal(Stacked{Sine{X(1.0 / 697)}, Sine{X(1.0 / 1633)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'B': NewPCMSignal(Stacked{Sine{X(1.0 / 770)}, Sine{X(1.0 / 1633)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'C': NewPCMSignal(Stacked{Sine{X(1.0 / 852)}, Sine{X(1.0 / 1633)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'D': NewPCMSignal(Stacked{Sine{X(1.0 / 941)}, Sine{X(1.0 / 1633)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'*': NewPCMSignal(Stacked{Sine{X(1.0 / 941)}, Sine{X(1.0 / 1209)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
-		'#': NewPCMSignal(Stacked{Sine{X(1.0 / 941)}, Sine{X(1.0 / 1477)}}, length, uint32(sampleRate), 2).(PCM16bit).PCM,
+	var Tones = map[rune][]byte{
+		'0': tone(Stacked{Sine{Hz(941)}, Sine{Hz(1336)}}, length),
+		'1': tone(Stacked{Sine{Hz(697)}, Sine{Hz(1209)}}, length),
+		'2': tone(Stacked{Sine{Hz(697)}, Sine{Hz(1336)}}, length),
+		'3': tone(Stacked{Sine{Hz(697)}, Sine{Hz(1477)}}, length),
+		'4': tone(Stacked{Sine{Hz(770)}, Sine{Hz(1209)}}, length),
+		'5': tone(Stacked{Sine{Hz(770)}, Sine{Hz(1336)}}, length),
+		'6': tone(Stacked{Sine{Hz(770)}, Sine{Hz(1477)}}, length),
+		'7': tone(Stacked{Sine{Hz(852)}, Sine{Hz(1209)}}, length),
+		'8': tone(Stacked{Sine{Hz(852)}, Sine{Hz(1336)}}, length),
+		'9': tone(Stacked{Sine{Hz(852)}, Sine{Hz(1477)}}, length),
+		'A': tone(Stacked{Sine{Hz(697)}, Sine{Hz(1633)}}, length),
+		'B': tone(Stacked{Sine{Hz(770)}, Sine{Hz(1633)}}, length),
+		'C': tone(Stacked{Sine{Hz(852)}, Sine{Hz(1633)}}, length),
+		'D': tone(Stacked{Sine{Hz(941)}, Sine{Hz(1633)}}, length),
+		'*': tone(Stacked{Sine{Hz(941)}, Sine{Hz(1209)}}, length),
+		'#': tone(Stacked{Sine{Hz(941)}, Sine{Hz(1477)}}, length),
 	}
 
-	var gapPCM = NewPCMSignal(Constant{0}, gap, uint32(sampleRate), 2).(PCM16bit).PCM
+	var gapPCM = tone(Constant{0}, gap)
 	rr := bufio.NewReader(os.Stdin)
 	for {
 		Rune, _, err := rr.ReadRune()
@@ -58,8 +64,8 @@ func main() {
 		} else if err != nil {
 			panic(err)
 		}
-		io.Copy(os.Stdout, bytes.NewReader(Tones[Rune].Data))
-		io.Copy(os.Stdout, bytes.NewReader(gapPCM.Data))
+		os.Stdout.Write(Tones[Rune])
+		os.Stdout.Write(gapPCM)
 	}
 	
 	os.Stdout.Close()
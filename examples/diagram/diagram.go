@@ -0,0 +1,42 @@
+// command for piping from gob encodings of Signals to diagrams of them, GraphViz DOT or Mermaid.
+
+// example usage (to view a tone's definition):-
+
+// ./diagram < gobs/RingingTone.gob | dot -Tsvg > RingingTone.svg
+
+// for Mermaid, (which can be put in markdown, in a ```mermaid block):
+// ./diagram -mermaid < gobs/RingingTone.gob
+package main
+
+import (
+	"bufio"
+	"flag"
+	"os"
+)
+
+import signals "github.com/splace/signals"
+
+func main() {
+	help := flag.Bool("help", false, "display help/usage.")
+	mermaid := flag.Bool("mermaid", false, "write a Mermaid flowchart, rather than GraphViz DOT.")
+	flag.Parse()
+	if *help {
+		flag.PrintDefaults()
+		os.Exit(0)
+	}
+	var s signals.Signal
+	err := signals.ReadGOB(bufio.NewReader(os.Stdin), &s)
+	if err != nil {
+		panic("unable to load." + err.Error())
+	}
+	out := bufio.NewWriter(os.Stdout)
+	if *mermaid {
+		err = signals.WriteMermaid(out, s)
+	} else {
+		err = signals.WriteDOT(out, s)
+	}
+	if err != nil {
+		panic("unable to write." + err.Error())
+	}
+	out.Flush()
+}
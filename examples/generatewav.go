@@ -6,10 +6,10 @@ import (
 	"os"
 )
 
-var OneSecond = X(1)
+var OneSecond = Seconds(1)
 
 func main() {
-	signal := Modulated{Sine{OneSecond/100},NewConstant(-6)}
+	signal := Modulated{Sine{Hz(100)},NewConstant(-6)}
 	// save file named after the go code of the signal
 	file, err := os.Create(fmt.Sprintf("%+v.wav", signal)) 
 	if err != nil {
@@ -0,0 +1,37 @@
+// command for piping from Standard MIDI Files to wave format PCM data, each note a sine with an ADSR envelope.
+
+// example usage (to play a jingle):-
+
+// ./midi < jingle.mid | aplay
+
+// to specifiy sample rate, and precision:
+// ./midi -rate=22050 -bytes=1 < jingle.mid > jingle.wav
+package main
+
+import (
+	"bufio"
+	"flag"
+	"os"
+)
+
+import signals "github.com/splace/signals"
+
+func main() {
+	help := flag.Bool("help", false, "display help/usage.")
+	sampleRate := flag.Uint("rate", 44100, "samples per second.")
+	sampleBytes := flag.Uint("bytes", 2, "bytes per sample, one of: 1,2,3,4.")
+	flag.Parse()
+	if *help {
+		flag.PrintDefaults()
+		os.Exit(0)
+	}
+	s, err := signals.ReadMIDI(bufio.NewReader(os.Stdin), nil)
+	if err != nil {
+		panic("unable to load." + err.Error())
+	}
+	out := bufio.NewWriter(os.Stdout)
+	if err = signals.Encode(out, uint8(*sampleBytes), uint32(*sampleRate), s.MaxX(), s); err != nil {
+		panic("unable to write." + err.Error())
+	}
+	out.Flush()
+}
@@ -6,7 +6,7 @@ import (
 	. "github.com/splace/signals" 
 	"os"
 )
-var OneSecond = X(1)
+var OneSecond = Seconds(1)
 
 func save(file string,s PeriodicSignal){
 	wavFile, err := os.Create(file)
@@ -30,10 +30,10 @@ Line Busy Tone is 480 Hz and 630 Hz that is on and off every .5 seconds.
 */
 
 func main(){
-	save("AudibleRingTone.wav",Looped{Modulated{Pulse{OneSecond*2},Stacked{Sine{OneSecond/440},Sine{OneSecond/480}}},OneSecond*6})
-	save("ReceiverOffHookTone.wav",Modulated{Looped{Pulse{OneSecond/10},OneSecond/5}, Stacked{Sine{OneSecond/1400},Sine{OneSecond/2060}, Sine{OneSecond/2450}, Sine{OneSecond/2600}}})
-	save("NoSuchNumberTone.wav",Stacked{Sine{OneSecond/200},Sine{OneSecond/400}})
-	save("LineBusyTone.wav",Modulated{Looped{Pulse{OneSecond/4},OneSecond/2}, Stacked{Sine{OneSecond/480},Sine{OneSecond/630}}})
+	save("AudibleRingTone.wav",Looped{Modulated{Pulse{OneSecond*2},Stacked{Sine{Hz(440)},Sine{Hz(480)}}},OneSecond*6})
+	save("ReceiverOffHookTone.wav",Modulated{Looped{Pulse{Ms(100)},Ms(200)}, Stacked{Sine{Hz(1400)},Sine{Hz(2060)}, Sine{Hz(2450)}, Sine{Hz(2600)}}})
+	save("NoSuchNumberTone.wav",Stacked{Sine{Hz(200)},Sine{Hz(400)}})
+	save("LineBusyTone.wav",Modulated{Looped{Pulse{Ms(250)},Ms(500)}, Stacked{Sine{Hz(480)},Sine{Hz(630)}}})
 
 }
 
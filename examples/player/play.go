@@ -3,22 +3,15 @@ package main
 
 import (
 	"os/exec"
-	"io"
-	"bytes"
 )
 
 import . "github.com/splace/signals"
 
-var OneSecond = X(1)
+var OneSecond = Seconds(1)
 
 func play(s Signal) {
 	cmd := exec.Command("aplay","-f","S16","-r","44100")
-	out, in := io.Pipe()
-	go func() {
-		io.Copy(in, bytes.NewReader(NewPCMSignal(s, OneSecond*3, 44100, 2).(PCM16bit).PCM.Data))
-		in.Close()
-	}()
-	cmd.Stdin=out 
+	cmd.Stdin = NewSampleReader(SampleFormat{Rate: 44100, Bytes: 2}, Modulated{s, Pulse{OneSecond * 3}})
 	err := cmd.Run()
 	if err != nil {
 		panic(err)
@@ -26,6 +19,6 @@ func play(s Signal) {
 }
 
 func main(){
-	play(Looped{Modulated{Pulse{OneSecond}, Looped{Pulse{OneSecond*4/10}, OneSecond*6/10}, Stacked{Sine{OneSecond/450},Sine{OneSecond/400}}}, OneSecond*3})
+	play(Looped{Modulated{Pulse{OneSecond}, Looped{Pulse{Ms(400)}, Ms(600)}, Stacked{Sine{Hz(450)},Sine{Hz(400)}}}, OneSecond*3})
 }
 
@@ -7,7 +7,7 @@ import (
 	"os"
 )
 
-var OneSecond = X(1)
+var OneSecond = Seconds(1)
 
 func Saves(file string, s PeriodicSignal) {
 	err := SaveGOB(file, s)
@@ -45,11 +45,11 @@ Notes
 */
 
 func main() {
-	Saves("BusyTone", Modulated{Looped{Pulse{OneSecond * 375 / 1000}, OneSecond * 75 / 100}, Sine{OneSecond / 400}})
-	Saves("EngagedTone", Looped{Modulated{Composite{Modulated{Pulse{OneSecond * 4 / 10}, NewConstant(-6)}, Shifted{Pulse{OneSecond * 225 / 1000}, OneSecond * 75 / 100}}, Sine{OneSecond / 400}}, OneSecond * 15 / 10})
-	Saves("RingingTone", Looped{Modulated{Pulse{OneSecond}, Looped{Pulse{OneSecond * 4 / 10}, OneSecond * 6 / 10}, Stacked{Sine{OneSecond / 450}, Sine{OneSecond / 400}}}, OneSecond * 3})
-	Saves("NumberUnobtainableTone", Sine{OneSecond / 400})
-	Saves("dialTone", Stacked{Sine{OneSecond / 450}, Sine{OneSecond / 350}})
+	Saves("BusyTone", Modulated{Looped{Pulse{Ms(375)}, Ms(750)}, Sine{Hz(400)}})
+	Saves("EngagedTone", Looped{Modulated{Composite{Modulated{Pulse{Ms(400)}, NewConstant(-6)}, Shifted{Pulse{Ms(225)}, Ms(750)}}, Sine{Hz(400)}}, Ms(1500)})
+	Saves("RingingTone", Looped{Modulated{Pulse{OneSecond}, Looped{Pulse{Ms(400)}, Ms(600)}, Stacked{Sine{Hz(450)}, Sine{Hz(400)}}}, OneSecond * 3})
+	Saves("NumberUnobtainableTone", Sine{Hz(400)})
+	Saves("dialTone", Stacked{Sine{Hz(450)}, Sine{Hz(350)}})
 
 }
 
@@ -0,0 +1,160 @@
+package signals
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+func init() {
+	gob.Register(FadeIn{})
+	gob.Register(FadeOut{})
+	gob.Register(CrossfadeSequence{})
+}
+
+// Curve is the shape of a fade, how the level changes over its width.
+type Curve uint8
+
+const (
+	Linear      Curve = iota // level in proportion.
+	EqualPower               // a quarter sine, a crossfade of unrelated Signals keeps the same power.
+	Logarithmic              // dB in proportion, from fadeRange below, (a jump from zero at the start.)
+	SCurve                   // a half cosine, changing slowly at both ends.
+)
+
+// names of the Curves, as in text, JSON and Go source.
+var curveNames = [...]string{"Linear", "EqualPower", "Logarithmic", "SCurve"}
+
+func (c Curve) String() string {
+	if int(c) < len(curveNames) {
+		return curveNames[c]
+	}
+	return fmt.Sprintf("Curve(%d)", uint8(c))
+}
+
+// dB a Logarithmic fade starts from.
+const fadeRange = 60
+
+// level, as a fraction, at a fraction of the way through a fade in, fading out is the same backwards.
+func (c Curve) level(f float64) float64 {
+	switch {
+	case f <= 0:
+		return 0
+	case f >= 1:
+		return 1
+	}
+	switch c {
+	case EqualPower:
+		return math.Sin(f * math.Pi / 2)
+	case Logarithmic:
+		return Vol(fadeRange * (f - 1))
+	case SCurve:
+		return (1 - math.Cos(f*math.Pi)) / 2
+	}
+	return f
+}
+
+// FadeIn is a Signal whose values rise, from zero at x zero, to the Signal's over Width, with the shape of Curve.
+// it's zero for negative x's.
+type FadeIn struct {
+	Signal
+	Width x
+	Curve Curve
+}
+
+func (s FadeIn) property(p x) y {
+	switch {
+	case p < 0:
+		return 0
+	case p >= s.Width:
+		return s.Signal.property(p)
+	}
+	return scaleY64(s.Signal.property(p), s.Curve.level(float64(p)/float64(s.Width)))
+}
+
+// MaxX is the embedded Signal's, if it's a LimitedSignal, otherwise zero.
+func (s FadeIn) MaxX() x {
+	return limit(s.Signal)
+}
+
+// FadeOut is a LimitedSignal whose values fall, to zero at its MaxX, over Width, with the shape of Curve.
+type FadeOut struct {
+	LimitedSignal
+	Width x
+	Curve Curve
+}
+
+func (s FadeOut) property(p x) y {
+	end := s.LimitedSignal.MaxX()
+	switch {
+	case p > end:
+		return 0
+	case p <= end-s.Width:
+		return s.LimitedSignal.property(p)
+	}
+	return scaleY64(s.LimitedSignal.property(p), s.Curve.level(float64(end-p)/float64(s.Width)))
+}
+
+// CrossfadeSequence is a LimitedSignal, generated by appending together LimitedSignals, as Sequenced, except each overlaps the one before by Overlap, fading out as the next fades in, with the shape of Curve.
+// where they overlap, values are added, clipped to unitY.
+// Overlap should be no more than half the shortest MaxX, (except for the first and last.)
+type CrossfadeSequence struct {
+	Overlap        x
+	Curve          Curve
+	LimitedSignals []LimitedSignal
+}
+
+// NewCrossfadeSequence returns a CrossfadeSequence of LimitedSignals.
+func NewCrossfadeSequence(overlap x, curve Curve, ss ...LimitedSignal) CrossfadeSequence {
+	return CrossfadeSequence{overlap, curve, ss}
+}
+
+func (c CrossfadeSequence) property(p x) (total y) {
+	last := len(c.LimitedSignals) - 1
+	var start x
+	for i, s := range c.LimitedSignals {
+		if i > 0 && p < start {
+			break
+		}
+		l := s.MaxX()
+		if q := p - start; q < l {
+			v := s.property(q)
+			level := 1.0
+			if i > 0 && q < c.Overlap {
+				level = c.Curve.level(float64(q) / float64(c.Overlap))
+			}
+			if i < last && q > l-c.Overlap {
+				level *= c.Curve.level(float64(l-q) / float64(c.Overlap))
+			}
+			if level != 1 {
+				v = scaleY64(v, level)
+			}
+			total = clipAdd(total, v)
+		}
+		start += l - c.Overlap
+	}
+	return
+}
+
+// sum of the MaxX's, less the overlaps.
+func (c CrossfadeSequence) MaxX() (max x) {
+	for i, s := range c.LimitedSignals {
+		if i > 0 {
+			max -= c.Overlap
+		}
+		max += s.MaxX()
+	}
+	return
+}
+
+// sum of two y's, clipped to full scale, (rather than overflowing.)
+func clipAdd(a, b y) y {
+	s := a + b
+	switch {
+	case a > 0 && b > 0 && (s < a || s > unitY):
+		return unitY
+	case a < 0 && b < 0 && (s > a || s < -unitY):
+		return -unitY
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+package signals
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func ExampleCrossfadeSequence() {
+	tone := Sine{Hz(697)}
+	s := NewCrossfadeSequence(Ms(5), EqualPower, Modulated{tone, Pulse{Ms(100)}}, Pulse{Ms(50)}, Modulated{tone, Pulse{Ms(100)}})
+	fmt.Printf("%.1fms\n", s.MaxX().Ms())
+	// Output:
+	// 240.0ms
+}
+
+func TestFadesCurves(t *testing.T) {
+	for _, c := range []Curve{Linear, EqualPower, Logarithmic, SCurve} {
+		if c.level(0) != 0 || c.level(-1) != 0 || c.level(1) != 1 || c.level(2) != 1 {
+			t.Error(c)
+		}
+		for f := 0.0; f < 1; f += 1.0 / 64 {
+			if c.level(f+1.0/64) <= c.level(f) {
+				t.Error(c, f)
+			}
+		}
+	}
+	for f := 0.0; f <= 1; f += 1.0 / 64 {
+		if p := math.Pow(EqualPower.level(f), 2) + math.Pow(EqualPower.level(1-f), 2); math.Abs(p-1) > 1e-12 {
+			t.Error(f, p)
+		}
+		if l := Linear.level(f) + Linear.level(1-f); math.Abs(l-1) > 1e-12 {
+			t.Error(f, l)
+		}
+	}
+	if l := Logarithmic.level(0.5); math.Abs(DB(l)+30) > 1e-9 {
+		t.Error(DB(l))
+	}
+}
+
+func TestFades(t *testing.T) {
+	in := FadeIn{Constant{unitY}, Ms(10), Linear}
+	for _, c := range []struct {
+		p x
+		v float64
+	}{{-Ms(1), 0}, {0, 0}, {Ms(5), 0.5}, {Ms(10), 1}, {Ms(20), 1}} {
+		if v := float64(in.property(c.p)) / float64(unitY); math.Abs(v-c.v) > 1e-9 {
+			t.Error(c.p, v, c.v)
+		}
+	}
+	if in.MaxX() != 0 || (FadeIn{Pulse{Ms(30)}, Ms(10), SCurve}).MaxX() != Ms(30) {
+		t.Error(in.MaxX())
+	}
+	out := FadeOut{Pulse{Ms(30)}, Ms(10), Linear}
+	for _, c := range []struct {
+		p x
+		v float64
+	}{{0, 1}, {Ms(20), 1}, {Ms(25), 0.5}, {Ms(30), 0}, {Ms(31), 0}} {
+		if v := float64(out.property(c.p)) / float64(unitY); math.Abs(v-c.v) > 1e-9 {
+			t.Error(c.p, v, c.v)
+		}
+	}
+	if out.MaxX() != Ms(30) {
+		t.Error(out.MaxX())
+	}
+}
+
+func TestFadesCrossfadeSequence(t *testing.T) {
+	// Pulses crossfaded linearly, sum to a level that doesn't change.
+	s := NewCrossfadeSequence(Ms(10), Linear, Pulse{Ms(30)}, Pulse{Ms(40)}, Pulse{Ms(30)})
+	if s.MaxX() != Ms(30)-Ms(10)+Ms(40)-Ms(10)+Ms(30) {
+		t.Error(s.MaxX())
+	}
+	for p := x(0); p <= Ms(80); p += Ms(1) / 3 {
+		if v := float64(s.property(p)) / float64(unitY); math.Abs(v-1) > 1e-9 {
+			t.Fatal(p, v)
+		}
+	}
+	if s.property(Ms(81)) != 0 {
+		t.Error(s.property(Ms(81)))
+	}
+	// equal power, so the levels sum to more than one, in the middle, clipped.
+	if v := (CrossfadeSequence{Ms(10), EqualPower, []LimitedSignal{Pulse{Ms(30)}, Pulse{Ms(30)}}}).property(Ms(25)); v != unitY {
+		t.Error(v)
+	}
+	// fading, in and out, at the joins.
+	sine := Modulated{Sine{Ms(3)}, Pulse{Ms(30)}}
+	s = NewCrossfadeSequence(Ms(10), SCurve, sine, Pulse{Ms(40)}, Modulated{Square{Ms(7)}, Pulse{Ms(20)}})
+	for p := x(0); p <= Ms(30); p += Ms(1) / 3 {
+		if s.property(p) != sumFaded(p, sine, 0, 0, Ms(30), Ms(10), SCurve)+sumFaded(p, Pulse{Ms(40)}, Ms(30)-Ms(10), Ms(10), Ms(30)-Ms(10)+Ms(40), Ms(10), SCurve) {
+			t.Fatal(p)
+		}
+	}
+	// without overlap, the same as Sequenced.
+	s = NewCrossfadeSequence(0, EqualPower, Pulse{Ms(3)}, Offset{Pulse{Ms(3)}, Ms(2)}, Pulse{Ms(2)})
+	sq := Sequenced{Pulse{Ms(3)}, Offset{Pulse{Ms(3)}, Ms(2)}, Pulse{Ms(2)}}
+	if s.MaxX() != sq.MaxX() {
+		t.Error(s.MaxX(), sq.MaxX())
+	}
+	for p := -Ms(1); p < Ms(14); p += Ms(1) / 4 {
+		// at the joins, where Sequenced has the next, the crossfade has both.
+		if p != Ms(3) && p != Ms(8) && s.property(p) != sq.property(p) {
+			t.Error(p, s.property(p), sq.property(p))
+		}
+	}
+	if (CrossfadeSequence{}).MaxX() != 0 || (CrossfadeSequence{}).property(0) != 0 {
+		t.Error("empty")
+	}
+}
+
+// value of a Signal, at p, starting at an x, faded in at its start and out before an end, as a CrossfadeSequence member.
+func sumFaded(p x, s Signal, start, in, end, out x, c Curve) y {
+	q := p - start
+	if q < 0 || p > end {
+		return 0
+	}
+	level := 1.0
+	if in > 0 && q < in {
+		level = c.level(float64(q) / float64(in))
+	}
+	if p > end-out {
+		level *= c.level(float64(end-p) / float64(out))
+	}
+	if level == 1 {
+		return s.property(q)
+	}
+	return scaleY64(s.property(q), level)
+}
+
+// Curves are named in text, JSON and Go source, and out of range ones aren't written or read.
+func TestFadesCurveNames(t *testing.T) {
+	for c, name := range []string{"Linear", "EqualPower", "Logarithmic", "SCurve"} {
+		s := FadeIn{Sine{unitX / 400}, unitX / 10, Curve(c)}
+		if text, err := FormatSignal(s); err != nil || !strings.Contains(text, name+")") {
+			t.Error(text, err)
+		}
+		var b bytes.Buffer
+		if err := WriteJSON(&b, s); err != nil || !strings.Contains(b.String(), `"Curve": "`+name+`"`) {
+			t.Error(b.String(), err)
+		}
+		if source, err := GoSource(s); err != nil || !strings.Contains(source, "Curve: signals."+name+"}") {
+			t.Error(source, err)
+		}
+	}
+	bad := FadeIn{Sine{unitX / 400}, unitX / 10, SCurve + 1}
+	if _, err := FormatSignal(bad); err == nil {
+		t.Error("out of range Curve formatted.")
+	}
+	if err := WriteGOBContainer(&bytes.Buffer{}, GOBHeader{}, bad); err == nil {
+		t.Error("out of range Curve written.")
+	}
+	for _, text := range []string{"FadeIn(Sine(1), 1, Cubic)", "FadeIn(Sine(1), 1, 4)", "FadeIn(Sine(1), 1, 3)"} {
+		if _, err := ParseSignal(text); err == nil || !strings.Contains(err.Error(), "is not a Curve") {
+			t.Error(text, err)
+		}
+	}
+	var s Signal
+	if err := ReadJSON(strings.NewReader(`{"Version":1,"Signal":{"Type":"FadeIn","Signal":{"Type":"Sine","Cycle":"1"},"Width":"1","Curve":"Curve(4)"}}`), &s); err == nil {
+		t.Error("out of range Curve read from JSON.")
+	}
+	gs, err := toGOBSignal(FadeIn{Sine{unitX / 400}, unitX / 10, SCurve})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs.Ints[len(gs.Ints)-1] = 256
+	if _, err := gs.signal(); err == nil || !strings.Contains(err.Error(), "Unsupported Curve") {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,65 @@
+package signals
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// in-place, radix-2, fast fourier transform. length must be a power of two.
+// inverse isn't scaled, so a round trip multiplies by the length.
+func fft(a []complex128, inverse bool) {
+	n := len(a)
+	// bit-reversed re-ordering
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	for length := 2; length <= n; length <<= 1 {
+		angle := 2 * math.Pi / float64(length)
+		if !inverse {
+			angle = -angle
+		}
+		step := cmplx.Rect(1, angle)
+		for i := 0; i < n; i += length {
+			w := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				u, v := a[i+k], a[i+k+length/2]*w
+				a[i+k], a[i+k+length/2] = u+v, u-v
+				w *= step
+			}
+		}
+	}
+}
+
+// the smallest power of two not less than n.
+func powerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// property values, scaled so unitY is 1, from a Signal sampled n times from start.
+func sampled(s Signal, start, samplePeriod x, n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = float64(s.property(start+x(i)*samplePeriod)) / float64(unitY)
+	}
+	return v
+}
+
+// complex, zero padded to size, copy of some samples.
+func padded(v []float64, size int) []complex128 {
+	c := make([]complex128, size)
+	for i := range v {
+		c[i] = complex(v[i], 0)
+	}
+	return c
+}
@@ -0,0 +1,151 @@
+//go:build float64
+// +build float64
+
+package signals
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// an alternative representation, float64 x and y, built with '-tags float64', x is in seconds, with about 15 significant digits, for very small or very large x's.
+// y's are a fraction of one, with more resolution near zero than int64, but less near one.
+// the Example graphs show each representation's rounding, so signals_test.go and ADSR_test.go have versions for this one, with its output.
+// GOB containers hold x's and y's as floats, and record that they do, so are converted when read by the int64 representation, and vice versa.
+
+// x and y are floats.
+const floatXY = true
+
+// the x represents a value from -infinity to +infinity, but is actually limited by its current underlying representation.
+// -ve x's are considered imaginary, not used, unless a Delay makes them +ve.
+type x float64 // current underlying representation
+const xBits = 64
+
+const unitX = x(1)
+
+// the y type represents a value between +unitY and -unitY.
+type y float64
+
+const unitY y = 1
+
+// bits of a fullScale y.
+const yBits = 64
+
+// as with int64, float64 sourced Signals are scaled to just below unitY, so exactly unitY is only from exact sources.
+const unitYfloat64 float64 = float64(unitY) - 1.0/(1<<53)
+
+// an x multiplied by a float.
+// float32's are taken as the shortest decimal that they are, so, for example, 1/float32(8000) is exactly 1/8000 as a float64.
+func scaleX32(d x, m float32) x {
+	return d * x(float32Decimal(m))
+}
+
+func scaleX64(d x, m float64) x {
+	return d * x(m)
+}
+
+// a y multiplied by a float.
+func scaleY32(d y, m float32) y {
+	return d * y(float32Decimal(m))
+}
+
+func scaleY64(d y, m float64) y {
+	return d * y(m)
+}
+
+func float32Decimal(f float32) float64 {
+	d, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return d
+}
+
+// the number of whole samplePeriods in p, towards zero.
+// within a billionth of a sample of a whole number is taken to be it, so the x's of samples, made by multiplying, give back their index.
+func sampleIndex(p, samplePeriod x) int {
+	q := float64(p / samplePeriod)
+	if n := math.Round(q); math.Abs(q-n) < 1e-9 {
+		return int(n)
+	}
+	return int(q)
+}
+
+// the remainder of p/d, with the sign of p.
+func modX(p, d x) x {
+	return x(math.Mod(float64(p), float64(d)))
+}
+
+// the largest relative error of an x, from a few roundings of a float64.
+func precisionX(p x) float64 {
+	return 1e-15
+}
+
+// the product of two y's, each as a fraction of unitY.
+func multiplyY(v1, v2 y) y {
+	return v1 * v2
+}
+
+// a y scaled so unitY is math.MaxInt64, the form PCM encoding is done from.
+func fullScale(v y) int64 {
+	switch {
+	case v >= unitY:
+		return math.MaxInt64
+	case v <= -unitY:
+		return -math.MaxInt64
+	}
+	return int64(float64(v) * (1 << 63))
+}
+
+// a y from a fullScale value.
+func fromFullScale(v int64) y {
+	return y(float64(v) / (1 << 63))
+}
+
+// an x from nanoseconds, as GOB containers hold them when written by the int64 representation.
+func xFromNanoseconds(ns int64) x {
+	return x(float64(ns) / 1e9)
+}
+
+// an x from seconds, as GOB containers hold them when written by this representation.
+func xFromSeconds(s float64) x {
+	return x(s)
+}
+
+// a y from a fraction of one, as GOB containers hold them when written by this representation.
+func yFromFloat(f float64) y {
+	return y(f)
+}
+
+// seed from an x, the same for the same x.
+// whole nanoseconds seed as the int64 representation, so, for example, Noise is the same.
+func seedX(p x) int64 {
+	if ns := float64(p) * 1e9; ns == math.Trunc(ns) && math.Abs(ns) < 1<<63 {
+		return int64(ns)
+	}
+	return int64(math.Float64bits(float64(p)))
+}
+
+// exact fraction of unitX.
+func ratX(p x) *big.Rat {
+	return new(big.Rat).SetFloat64(float64(p))
+}
+
+// nearest x to a fraction of unitX.
+func xRat(r *big.Rat) (x, error) {
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		return 0, errors.New(r.FloatString(3) + " is out of range.")
+	}
+	return x(f), nil
+}
+
+// exact fraction of unitY.
+func ratY(v y) *big.Rat {
+	return new(big.Rat).SetFloat64(float64(v))
+}
+
+// nearest y to a fraction of unitY.
+func yRat(r *big.Rat) (y, error) {
+	f, _ := r.Float64()
+	return y(f), nil
+}
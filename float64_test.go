@@ -0,0 +1,32 @@
+//go:build float64
+// +build float64
+
+package signals
+
+import (
+	"math"
+	"testing"
+)
+
+// the most two ways of getting the same values can differ by.
+const roundingError y = 1e-12
+
+func TestFloat64Range(t *testing.T) {
+	// a femtosecond cycle, a million cycles in.
+	s := Sine{unitX / 1e15}
+	if v := s.property(s.Cycle*1e6 + s.Cycle/4); math.Abs(float64(v-unitY)) > 1e-9 {
+		t.Error(v)
+	}
+	if e := MaxError(Looped{s, s.Cycle}, s, 0, s.Cycle*10, s.Cycle/100); e > 1e-9 {
+		t.Error(e)
+	}
+	text, err := FormatSignal(s)
+	if err != nil || text != "Sine(1000000000000000Hz)" {
+		t.Error(text, err)
+	}
+	// a thousand year cycle.
+	slow := Sine{unitX * 3600 * 24 * 365 * 1000}
+	if v := slow.property(slow.Cycle / 4); math.Abs(float64(v-unitY)) > 1e-12 {
+		t.Error(v)
+	}
+}
@@ -37,8 +37,8 @@ func Encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 // unbuffered encode Signals as PCM data,in a Riff wave container.
 func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...Signal) (err error) {
 	samplePeriod := X(1 / float32(sampleRate))
-	samples := uint32(length/samplePeriod) + 1
-	binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'R', 'I', 'F', 'F'}, samples*uint32(sampleBytes) + 36})
+	samples := uint32(sampleIndex(length, samplePeriod)) + 1
+	binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'R', 'I', 'F', 'F'}, samples*uint32(sampleBytes)*uint32(len(ss)) + 36})
 	w.Write([]byte{'W', 'A', 'V', 'E'})
 	binary.Write(w, binary.LittleEndian, chunkHeader{[4]byte{'f', 'm', 't', ' '}, 16})
 	binary.Write(w, binary.LittleEndian, formatChunk{
@@ -56,7 +56,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try short-cuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM8bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples : -offsetSamples+int(samples)])
 				} else {
@@ -78,6 +78,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 						w.Close()
 					}
 				}()
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 1); err == nil && i < samples; i++ {
 					sample[0] = encodePCM8bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -92,7 +93,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try shortcuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM16bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples*2 : (int(samples)-offsetSamples)*2])
 				} else {
@@ -115,6 +116,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 					}
 				}()
 
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 2); err == nil && i < samples; i++ {
 					sample[0], sample[1] = encodePCM16bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -129,7 +131,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try shortcuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM24bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples*3 : (int(samples)-offsetSamples)*3])
 				} else {
@@ -151,6 +153,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 						w.Close()
 					}
 				}()
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 3); err == nil && i < samples; i++ {
 					sample[0], sample[1], sample[2] = encodePCM24bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -165,7 +168,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try shortcuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM32bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples*4 : (int(samples)-offsetSamples)*4])
 				} else {
@@ -187,6 +190,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 						w.Close()
 					}
 				}()
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 4); err == nil && i < samples; i++ {
 					sample[0], sample[1], sample[2], sample[3] = encodePCM32bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -201,7 +205,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try shortcuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM48bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples*6 : (int(samples)-offsetSamples)*6])
 				} else {
@@ -223,6 +227,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 						w.Close()
 					}
 				}()
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 6); err == nil && i < samples; i++ {
 					sample[0], sample[1], sample[2], sample[3], sample[4], sample[5] = encodePCM48bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -237,7 +242,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 			// try shortcuts first
 			offset, ok := s.(Offset)
 			if pcms, ok2 := offset.LimitedSignal.(PCM64bit); ok && ok2 && pcms.samplePeriod == samplePeriod && pcms.MaxX() >= length-offset.Offset {
-				offsetSamples := int(offset.Offset / samplePeriod)
+				offsetSamples := sampleIndex(offset.Offset, samplePeriod)
 				if offsetSamples < 0 {
 					w.Write(pcms.Data[-offsetSamples*8 : (int(samples)-offsetSamples)*8])
 				} else {
@@ -259,6 +264,7 @@ func encode(w io.Writer, sampleBytes uint8, sampleRate uint32, length x, ss ...S
 						w.Close()
 					}
 				}()
+				var err error // per channel, not encode's.
 				for i, sample := uint32(0), make([]byte, 8); err == nil && i < samples; i++ {
 					sample[0], sample[1], sample[2], sample[3], sample[4], sample[5], sample[6], sample[7] = encodePCM64bit(s.property(x(i) * samplePeriod))
 					_, err = w.Write(sample)
@@ -409,7 +415,7 @@ func readWaveHeader(wav io.Reader) (uint32, *formatChunk, error) {
 		return 0, nil, errParsing{errors.New("Not RIFF format."),wav}
 	}
 	b:=make([]byte,4)
-	if _,err:=wav.Read(b); err != nil{
+	if _,err:=io.ReadFull(wav,b); err != nil{
 		return 0, nil, errParsing{err,wav}
 	}
 	if b[0] != 'W' || b[1] != 'A' || b[2] != 'V' || b[3] != 'E' {
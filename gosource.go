@@ -0,0 +1,238 @@
+package signals
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// the import path used in generated Go source.
+const importPath = "github.com/splace/signals"
+
+// GoSource returns a Go expression that makes a Signal, using this package's exported types and functions, qualified as "signals.".
+// x and y values are written in seconds, frequency or dB, when that is exact, so the Signal made is the same. (except PCM sample periods, which are made from a sample rate, and have a comment when that doesn't make them exactly.)
+func GoSource(s Signal) (string, error) {
+	g := goGenerator{imports: map[string]bool{}}
+	if err := g.signal(s); err != nil {
+		return "", err
+	}
+	b, err := format.Source(g.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteGoSource writes a formatted Go source file, for a package, that declares a variable, with name, initialised to the Signal.
+func WriteGoSource(w io.Writer, packageName, name string, s Signal) error {
+	g := goGenerator{imports: map[string]bool{importPath: true}}
+	if err := g.signal(s); err != nil {
+		return err
+	}
+	imports := make([]string, 0, len(g.imports))
+	for i := range g.imports {
+		imports = append(imports, strconv.Quote(i))
+	}
+	sort.Strings(imports)
+	var f bytes.Buffer
+	fmt.Fprintf(&f, "// Code generated by signals.WriteGoSource. DO NOT EDIT.\n\npackage %s\n\n", packageName)
+	for _, i := range imports {
+		fmt.Fprintf(&f, "import %s\n", i)
+	}
+	fmt.Fprintf(&f, "\nvar %s = %s\n", name, g.String())
+	b, err := format.Source(f.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+type goGenerator struct {
+	bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *goGenerator) signal(s Signal) error {
+	name, values, err := describe(s)
+	if err != nil {
+		return err
+	}
+	st := signalTypes[name]
+	switch name {
+	case "Constant":
+		v := values[0].(y)
+		if db := DB(float64(v) / unitYfloat64); v > 0 && NewConstant(db).Constant == v {
+			fmt.Fprintf(g, "signals.NewConstant(%s)", strconv.FormatFloat(db, 'g', -1, 64))
+			return nil
+		}
+		fmt.Fprintf(g, "signals.Constant{Constant: %s}", goY(v))
+	case "Noise":
+		g.WriteString("signals.NewNoise()")
+	case "ADSREnvelope", "Segmented", "Triggered", "Cached", "Buffered", "CrossfadeSequence", "IndexedSequence", "Timeline":
+		fmt.Fprintf(g, "signals.New%s(", name)
+		for i, v := range values {
+			if i > 0 {
+				g.WriteString(", ")
+			}
+			if err := g.value(v, st.parameter(i).kind); err != nil {
+				return err
+			}
+		}
+		g.WriteString(")")
+	case "Modulated", "Composite", "Stacked", "Sequenced":
+		fmt.Fprintf(g, "signals.%s{", name)
+		for _, v := range values {
+			g.WriteString("\n")
+			if err := g.signal(v.(Signal)); err != nil {
+				return err
+			}
+			g.WriteString(",")
+		}
+		if len(values) > 0 {
+			g.WriteString("\n")
+		}
+		g.WriteString("}")
+	case "PCM8bit", "PCM16bit", "PCM24bit", "PCM32bit", "PCM48bit", "PCM64bit":
+		p := values[0].(x)
+		rate, exact := pcmRate(p)
+		if !exact {
+			fmt.Fprintf(g, "/* sample period %s */ ", formatX(p))
+		}
+		fmt.Fprintf(g, "signals.New%s(%d, []byte(%s))", name, rate, strconv.Quote(string(values[1].([]byte))))
+	case "Wave":
+		fmt.Fprintf(g, "&signals.Wave{URL: %s}", strconv.Quote(values[0].(string)))
+	default:
+		// keyed struct literal, parameters are named the same as the fields.
+		fmt.Fprintf(g, "signals.%s{", name)
+		for i, v := range values {
+			if i > 0 {
+				g.WriteString(", ")
+			}
+			fmt.Fprintf(g, "%s: ", st.parameters[i].name)
+			if err := g.value(v, st.parameters[i].kind); err != nil {
+				return err
+			}
+		}
+		g.WriteString("}")
+	}
+	return nil
+}
+
+func (g *goGenerator) value(v interface{}, k kind) error {
+	switch vt := v.(type) {
+	case Signal:
+		return g.signal(vt)
+	case x:
+		g.WriteString(goX(vt, k == periodKind))
+	case y:
+		g.WriteString(goY(vt))
+	case int:
+		g.WriteString(strconv.Itoa(vt))
+	case Curve:
+		g.WriteString("signals." + vt.String())
+	case float32:
+		g.WriteString(strconv.FormatFloat(float64(vt), 'g', -1, 32))
+	case float64:
+		g.WriteString(strconv.FormatFloat(vt, 'g', -1, 64))
+	case bool:
+		g.WriteString(strconv.FormatBool(vt))
+	case string:
+		g.WriteString(strconv.Quote(vt))
+	case *big.Int:
+		g.imports["math/big"] = true
+		fmt.Fprintf(g, "func() big.Int { i, _ := new(big.Int).SetString(%q, 2); return *i }()", vt.Text(2))
+	default:
+		return errors.New(fmt.Sprintf("no Go source for %T.", v))
+	}
+	return nil
+}
+
+// sample rate for a sample period, of those near the nearest, that NewPCM makes it from, or that unitX divided by makes it, (as unitX/44100 does), the one with the most trailing zeros, preferring one NewPCM makes it from, which is exact.
+// if none do, the nearest.
+func pcmRate(p x) (rate uint32, exact bool) {
+	nearest := int64(math.Round(float64(unitX) / float64(p)))
+	if nearest < 1 || nearest > math.MaxUint32 {
+		return 1, false
+	}
+	zeros := -1
+	for r := nearest - 100; r <= nearest+100; r++ {
+		if r < 1 || r > math.MaxUint32 {
+			continue
+		}
+		made := NewPCM(uint32(r), nil).samplePeriod == p
+		if !made && unitX/x(r) != p {
+			continue
+		}
+		z := 0
+		for t := r; t%10 == 0; t /= 10 {
+			z++
+		}
+		if z > zeros || z == zeros && made && !exact {
+			rate, zeros, exact = uint32(r), z, made
+		}
+	}
+	if zeros < 0 {
+		return uint32(nearest), false
+	}
+	return rate, exact
+}
+
+// Go expression for an x, seconds or, for periods, one over a frequency, falling back to a whole number of nanoseconds.
+func goX(p x, period bool) string {
+	if period && p > 0 {
+		if s := formatPeriod(p); s[len(s)-2:] == "Hz" {
+			if f, _ := strconv.ParseFloat(s[:len(s)-2], 64); X(1/f) == p {
+				return fmt.Sprintf("signals.X(1.0 / %s)", s[:len(s)-2])
+			}
+		}
+	}
+	if p < 0 && p != -p {
+		return "-" + goX(-p, false)
+	}
+	s := formatX(p)
+	if f, _ := strconv.ParseFloat(s, 64); X(f) == p {
+		return "signals.X(" + s + ")"
+	}
+	return fmt.Sprintf("signals.X(1) / 1e9 * %d", int64(p))
+}
+
+// Go expression for a y, a whole fraction or a decimal fraction, falling back to an offset from the maximum.
+func goY(v y) string {
+	switch {
+	case v == 0:
+		return "signals.Y(0)"
+	case v == unitY:
+		return "signals.Y(1)"
+	case v == -unitY:
+		return "-signals.Y(1)"
+	}
+	for d := 2; d <= 1000; d++ {
+		if unitY/y(d) == v {
+			return fmt.Sprintf("signals.Y(1) / %d", d)
+		}
+		if -unitY/y(d) == v {
+			return fmt.Sprintf("-signals.Y(1) / %d", d)
+		}
+	}
+	f := float64(v) / float64(unitY)
+	for places := 1; places < 18; places++ {
+		s := strconv.FormatFloat(f, 'f', places, 64)
+		if g, _ := strconv.ParseFloat(s, 64); Y(g) == v {
+			return "signals.Y(" + s + ")"
+		}
+	}
+	if s := strconv.FormatFloat(f, 'g', -1, 64); Y(f) == v {
+		return "signals.Y(" + s + ")"
+	}
+	if v > 0 {
+		return fmt.Sprintf("signals.Y(1) - %d", int64(unitY-v))
+	}
+	return fmt.Sprintf("-signals.Y(1) + %d", int64(unitY+v))
+}
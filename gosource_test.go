@@ -0,0 +1,122 @@
+package signals
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	goparser "go/parser"
+	gotoken "go/token"
+	"go/types"
+	"testing"
+)
+
+func ExampleGoSource() {
+	s, _ := GoSource(Modulated{Looped{Pulse{unitX * 375 / 1000}, unitX * 75 / 100}, Sine{unitX / 400}, NewConstant(-6)})
+	fmt.Println(s)
+	// Output:
+	// signals.Modulated{
+	// 	signals.Looped{Signal: signals.Pulse{Width: signals.X(0.375)}, Loop: signals.X(0.75)},
+	// 	signals.Sine{Cycle: signals.X(1.0 / 400)},
+	// 	signals.NewConstant(-6),
+	// }
+}
+
+// the generated source type checks, against this package's, (for the build's representation.)
+func TestGoSourceCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks this package.")
+	}
+	fset, std := gotoken.NewFileSet(), importer.Default()
+	pkg, err := checkPackage(fset, std)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: packageImporter{pkg, std}}
+	for _, s := range builtins() {
+		var b bytes.Buffer
+		if err := WriteGoSource(&b, "tones", "Tone", s); err != nil {
+			t.Fatal(err)
+		}
+		f, err := goparser.ParseFile(fset, "tone.go", b.Bytes(), 0)
+		if err != nil {
+			t.Fatal(err, b.String())
+		}
+		if _, err := conf.Check("tones", fset, []*ast.File{f}, nil); err != nil {
+			t.Errorf("%T: %v\n%s", s, err, b.String())
+		}
+	}
+}
+
+// type check this package's source, with the build tags the test was built with, importing others with an Importer, (the generated source's must be the same one, so its packages are the same.)
+func checkPackage(fset *gotoken.FileSet, std types.Importer) (*types.Package, error) {
+	ctxt := build.Default
+	if floatXY {
+		ctxt.BuildTags = append(ctxt.BuildTags, "float64")
+	}
+	bp, err := ctxt.ImportDir(".", 0)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*ast.File, len(bp.GoFiles))
+	for i, name := range bp.GoFiles {
+		if files[i], err = goparser.ParseFile(fset, name, nil, 0); err != nil {
+			return nil, err
+		}
+	}
+	return (&types.Config{Importer: std}).Check(importPath, fset, files, nil)
+}
+
+// imports this package as checked, others as usual.
+type packageImporter struct {
+	pkg *types.Package
+	types.Importer
+}
+
+func (i packageImporter) Import(path string) (*types.Package, error) {
+	if path == i.pkg.Path() {
+		return i.pkg, nil
+	}
+	return i.Importer.Import(path)
+}
+
+func TestGoSourceValues(t *testing.T) {
+	xs := map[x]string{
+		unitX:       "signals.X(1)",
+		-unitX / 10: "-signals.X(0.1)",
+		unitX / 3:   "signals.X(0.333333333)",
+		unitX * 1e9: "signals.X(1000000000)",
+	}
+	ys := map[y]string{
+		unitY / 2:  "signals.Y(1) / 2",
+		-unitY / 3: "-signals.Y(1) / 3",
+		Y(.3):      "signals.Y(0.3)",
+	}
+	if floatXY {
+		xs[unitX/3] = "signals.X(0.3333333333333333)"
+	} else {
+		ys[unitY-1] = "signals.Y(1) - 1"
+	}
+	for v, source := range xs {
+		if s := goX(v, false); s != source {
+			t.Error(s, source)
+		}
+	}
+	for v, source := range ys {
+		if s := goY(v); s != source {
+			t.Error(s, source)
+		}
+	}
+	for _, rate := range []uint32{8000, 22050, 44100, 48000} {
+		if r, exact := pcmRate(NewPCM(rate, nil).samplePeriod); r != rate || !exact {
+			t.Error(rate, r, exact)
+		}
+	}
+	// periods of unitX over a rate, which NewPCM might not make, are from that rate.
+	for _, rate := range []uint32{8000, 22050, 44100, 48000, 96000} {
+		if r, _ := pcmRate(unitX / x(rate)); r != rate {
+			t.Error(rate, r)
+		}
+	}
+}
@@ -16,7 +16,7 @@ type Depiction struct {
 
 // makes a Depiction of a LimitedSignal, scaled to pxMaxx by pxMaxy pixels and sets the colours for above and below the value.
 func NewDepiction(s LimitedSignal, pxMaxX, pxMaxY int, below, above color.Color) Depiction {
-	return Depiction{s, image.Rect(0, -pxMaxY/2, pxMaxX, pxMaxY/2), int(int64(pxMaxX) * int64(unitX) / int64(s.MaxX())), below, above}
+	return Depiction{s, image.Rect(0, -pxMaxY/2, pxMaxX, pxMaxY/2), int(float64(pxMaxX) * float64(unitX) / float64(s.MaxX())), below, above}
 }
 
 func (i Depiction) Bounds() image.Rectangle {
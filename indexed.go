@@ -0,0 +1,140 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sort"
+)
+
+func init() {
+	gob.Register(IndexedSequence{})
+}
+
+// IndexedSequence is a LimitedSignal, the same as Sequenced, LimitedSignals appended together, but it keeps where each ends, so finds the one for an x by binary search, and has its MaxX without getting every one's.
+// members' MaxX's are got when they're added, so shouldn't change.
+// Append, Insert and Remove change it, (and its copies), updating where the members after any change end, they shouldn't be used while values are being got.
+// use NewIndexedSequence, an IndexedSequence literal is empty, and can't be added to.
+type IndexedSequence struct {
+	index *sequenceIndex
+}
+
+// members, their MaxX's, and the x where each ends, shared by copies of an IndexedSequence.
+type sequenceIndex struct {
+	members []LimitedSignal
+	lengths []x
+	ends    []x
+}
+
+// NewIndexedSequence returns an IndexedSequence of LimitedSignals.
+func NewIndexedSequence(ss ...LimitedSignal) IndexedSequence {
+	c := IndexedSequence{&sequenceIndex{}}
+	c.Append(ss...)
+	return c
+}
+
+func (c IndexedSequence) property(p x) y {
+	if c.index == nil {
+		return 0
+	}
+	ends := c.index.ends
+	i := sort.Search(len(ends), func(i int) bool { return p < ends[i] })
+	switch i {
+	case len(ends):
+		return 0
+	case 0:
+		return c.index.members[0].property(p)
+	}
+	return c.index.members[i].property(p - ends[i-1])
+}
+
+// where the last member ends.
+func (c IndexedSequence) MaxX() x {
+	if c.index == nil || len(c.index.ends) == 0 {
+		return 0
+	}
+	return c.index.ends[len(c.index.ends)-1]
+}
+
+// Len returns the number of members.
+func (c IndexedSequence) Len() int {
+	if c.index == nil {
+		return 0
+	}
+	return len(c.index.members)
+}
+
+// Member returns the i'th member, and the x it starts at.
+func (c IndexedSequence) Member(i int) (LimitedSignal, x) {
+	return c.index.members[i], c.start(i)
+}
+
+// LimitedSignals returns a copy of the members.
+func (c IndexedSequence) LimitedSignals() []LimitedSignal {
+	if c.index == nil {
+		return nil
+	}
+	return append([]LimitedSignal(nil), c.index.members...)
+}
+
+// Append adds LimitedSignals to the end.
+func (c IndexedSequence) Append(ss ...LimitedSignal) {
+	c.Insert(c.Len(), ss...)
+}
+
+// Insert adds LimitedSignals before the i'th member, (at the end for i of Len().)
+func (c IndexedSequence) Insert(i int, ss ...LimitedSignal) {
+	ix := c.index
+	lengths := make([]x, len(ss))
+	for j, s := range ss {
+		lengths[j] = s.MaxX()
+	}
+	ix.members = append(ix.members[:i], append(append([]LimitedSignal(nil), ss...), ix.members[i:]...)...)
+	ix.lengths = append(ix.lengths[:i], append(lengths, ix.lengths[i:]...)...)
+	ix.ends = append(ix.ends, lengths...)
+	ix.update(i)
+}
+
+// Remove removes the members from i up to, but not including, j.
+func (c IndexedSequence) Remove(i, j int) {
+	ix := c.index
+	ix.members = append(ix.members[:i], ix.members[j:]...)
+	ix.lengths = append(ix.lengths[:i], ix.lengths[j:]...)
+	ix.ends = ix.ends[:len(ix.lengths)]
+	ix.update(i)
+}
+
+// update ends, from the i'th, summing lengths in order, so the same as Sequenced, (for float64 x's.)
+func (ix *sequenceIndex) update(i int) {
+	var end x
+	if i > 0 {
+		end = ix.ends[i-1]
+	}
+	for ; i < len(ix.lengths); i++ {
+		end += ix.lengths[i]
+		ix.ends[i] = end
+	}
+}
+
+func (c IndexedSequence) start(i int) x {
+	if i == 0 {
+		return 0
+	}
+	return c.index.ends[i-1]
+}
+
+// GobEncode encodes the members.
+func (c IndexedSequence) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(c.LimitedSignals())
+	return b.Bytes(), err
+}
+
+// GobDecode makes an IndexedSequence from its members.
+func (c *IndexedSequence) GobDecode(data []byte) error {
+	var ss []LimitedSignal
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ss); err != nil {
+		return err
+	}
+	*c = NewIndexedSequence(ss...)
+	return nil
+}
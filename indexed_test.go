@@ -0,0 +1,102 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+)
+
+func ExampleIndexedSequence() {
+	s := NewIndexedSequence(Pulse{Ms(100)}, Pulse{Ms(50)})
+	s.Append(Pulse{Ms(25)})
+	s.Insert(0, Pulse{Ms(10)})
+	s.Remove(1, 2)
+	fmt.Println(s.Len())
+	fmt.Printf("%.1fms\n", s.MaxX().Ms())
+	// Output:
+	// 3
+	// 85.0ms
+}
+
+func TestIndexedSequence(t *testing.T) {
+	ss := []LimitedSignal{Pulse{Ms(3)}, Offset{Pulse{Ms(3)}, Ms(2)}, Modulated{Sine{Ms(1)}, Pulse{Ms(2)}}, Pulse{0}, Modulated{RampUp{Ms(4)}, Pulse{Ms(4)}}}
+	s := NewIndexedSequence(ss...)
+	sameAsSequenced(t, s, ss)
+	s.Append(Pulse{Ms(1)}, Pulse{Ms(2)})
+	ss = append(ss, Pulse{Ms(1)}, Pulse{Ms(2)})
+	sameAsSequenced(t, s, ss)
+	s.Insert(2, Modulated{RampUp{Ms(3)}, Pulse{Ms(3)}})
+	ss = append(ss[:2], append([]LimitedSignal{Modulated{RampUp{Ms(3)}, Pulse{Ms(3)}}}, ss[2:]...)...)
+	sameAsSequenced(t, s, ss)
+	s.Insert(0, Pulse{Ms(5)}, Pulse{Ms(1)})
+	ss = append([]LimitedSignal{Pulse{Ms(5)}, Pulse{Ms(1)}}, ss...)
+	sameAsSequenced(t, s, ss)
+	s.Remove(3, 6)
+	ss = append(ss[:3], ss[6:]...)
+	sameAsSequenced(t, s, ss)
+	s.Remove(0, 1)
+	ss = ss[1:]
+	sameAsSequenced(t, s, ss)
+	m, start := s.Member(1)
+	if m != ss[1] || start != ss[0].MaxX() {
+		t.Error(m, start)
+	}
+	s.Remove(0, s.Len())
+	sameAsSequenced(t, s, nil)
+	var empty IndexedSequence
+	if empty.MaxX() != 0 || empty.property(0) != 0 || empty.Len() != 0 {
+		t.Error("empty")
+	}
+}
+
+func TestIndexedSequenceGOB(t *testing.T) {
+	ss := []LimitedSignal{Pulse{Ms(3)}, Offset{Pulse{Ms(3)}, Ms(2)}, Modulated{RampUp{Ms(4)}, Pulse{Ms(4)}}}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(&struct{ Signal }{NewIndexedSequence(ss...)}); err != nil {
+		t.Fatal(err)
+	}
+	var d struct{ Signal }
+	if err := gob.NewDecoder(&b).Decode(&d); err != nil {
+		t.Fatal(err)
+	}
+	s, ok := d.Signal.(IndexedSequence)
+	if !ok {
+		t.Fatalf("%T", d.Signal)
+	}
+	sameAsSequenced(t, s, ss)
+}
+
+// an IndexedSequence has the same MaxX, and values, as a Sequenced of the same LimitedSignals.
+func sameAsSequenced(t *testing.T, s IndexedSequence, ss []LimitedSignal) {
+	t.Helper()
+	sq := Sequenced(ss)
+	if s.MaxX() != sq.MaxX() || s.Len() != len(ss) {
+		t.Fatal(s.MaxX(), sq.MaxX(), s.Len(), len(ss))
+	}
+	// between joins, where the float64 build can round to either side.
+	sameSampled(t, sq, s, -Ms(1)+Ms(1)/16, sq.MaxX()+Ms(1), Ms(1)/8, 1e-9)
+}
+
+func BenchmarkIndexedSequence(b *testing.B) {
+	ss := make([]LimitedSignal, 10000)
+	for i := range ss {
+		ss[i] = Modulated{Sine{Ms(1)}, Pulse{Ms(10)}}
+	}
+	s := NewIndexedSequence(ss...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.property(x(i%100000) * Ms(1))
+	}
+}
+
+func BenchmarkIndexedSequenceSequenced(b *testing.B) {
+	ss := make(Sequenced, 10000)
+	for i := range ss {
+		ss[i] = Modulated{Sine{Ms(1)}, Pulse{Ms(10)}}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ss.property(x(i%100000) * Ms(1))
+	}
+}
@@ -0,0 +1,138 @@
+//go:build !float64
+// +build !float64
+
+package signals
+
+import (
+	"math"
+	"math/big"
+)
+
+// the default representation, int64 x and y, x is in nanoseconds, for about ±292 years, (see float64.go for the alternative, built with '-tags float64'.)
+
+// x and y aren't floats.
+const floatXY = false
+
+// the x represents a value from -infinity to +infinity, but is actually limited by its current underlying representation.
+// -ve x's are considered imaginary, not used, unless a Delay makes them +ve.
+type x int64 // current underlying representation
+const xBits = 64
+
+// somewhere close to the middle of the resolution range, adjust if dealing with high accuracy but only either small or large values.
+const unitX = x(1000000000)
+
+// the y type represents a value between +unitY and -unitY.
+type y int64
+
+const unitY y = math.MaxInt64
+const yBits = 64
+const halfyBits = yBits / 2
+
+//const Halfy=2<<(HalfyBits-1)
+
+// float64 has less resolution than int64 at maxy, so need this to scale float64 sourced Signals to never overflow int64
+const unitYfloat64 float64 = float64(unitY - 512)
+
+// an x multiplied by a float, rounded to nearest. (float32 multiplied at float32 precision.)
+func scaleX32(d x, m float32) x {
+	return x(float32(d)*m + .5)
+}
+
+func scaleX64(d x, m float64) x {
+	return x(float64(d)*m + .5)
+}
+
+// a y multiplied by a float, rounded to nearest. (float32 multiplied at float32 precision.)
+func scaleY32(d y, m float32) y {
+	return y(float32(d)*m + .5)
+}
+
+func scaleY64(d y, m float64) y {
+	return y(float64(d)*m + .5)
+}
+
+// the number of whole samplePeriods in p, towards zero.
+func sampleIndex(p, samplePeriod x) int {
+	return int(p / samplePeriod)
+}
+
+// the remainder of p/d, with the sign of p.
+func modX(p, d x) x {
+	return p % d
+}
+
+// the largest relative error of an x, from being rounded to a whole number.
+func precisionX(p x) float64 {
+	return 0.5 / math.Abs(float64(p))
+}
+
+// the product of two y's, each as a fraction of unitY.
+func multiplyY(v1, v2 y) y {
+	//return (v1 / Halfy) * (v2 / Halfy)*2
+	return (v1 >> halfyBits) * (v2 >> halfyBits) * 2
+}
+
+// a y scaled so unitY is math.MaxInt64, the form PCM encoding is done from.
+func fullScale(v y) int64 {
+	return int64(v)
+}
+
+// a y from a fullScale value.
+func fromFullScale(v int64) y {
+	return y(v)
+}
+
+// an x from nanoseconds, as GOB containers hold them when written by this representation.
+func xFromNanoseconds(ns int64) x {
+	return x(ns)
+}
+
+// an x from seconds, as GOB containers hold them when written by the float64 representation, to the nearest nanosecond.
+func xFromSeconds(s float64) x {
+	ns := math.Round(s * float64(unitX))
+	switch {
+	case ns >= math.MaxInt64:
+		return math.MaxInt64
+	case ns <= math.MinInt64:
+		return math.MinInt64
+	}
+	return x(ns)
+}
+
+// a y from a fraction of one, as GOB containers hold them when written by the float64 representation.
+func yFromFloat(f float64) y {
+	switch {
+	case f >= 1:
+		return unitY
+	case f <= -1:
+		return -unitY
+	}
+	return y(f * unitYfloat64)
+}
+
+// seed from an x, the same for the same x.
+func seedX(p x) int64 {
+	return int64(p)
+}
+
+// exact fraction of unitX.
+func ratX(p x) *big.Rat {
+	return new(big.Rat).SetFrac64(int64(p), int64(unitX))
+}
+
+// nearest x to a fraction of unitX.
+func xRat(r *big.Rat) (x, error) {
+	i, err := rounded(new(big.Rat).Mul(r, big.NewRat(int64(unitX), 1)))
+	return x(i), err
+}
+
+// exact fraction of unitY.
+func ratY(v y) *big.Rat {
+	return new(big.Rat).SetFrac64(int64(v), int64(unitY))
+}
+
+// nearest y to a fraction of unitY.
+func yRat(r *big.Rat) (y, error) {
+	i, err := rounded(new(big.Rat).Mul(r, big.NewRat(int64(unitY), 1)))
+	return y(i), err
+}
@@ -0,0 +1,7 @@
+//go:build !float64
+// +build !float64
+
+package signals
+
+// the most two ways of getting the same values can differ by.
+const roundingError y = 0
@@ -0,0 +1,306 @@
+package signals
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+)
+
+// Instrument returns the LimitedSignal for a note, from its MIDI note number, (60 is middle C), its velocity, (1 to 127), and how long it's held.
+// its MaxX can be longer than the duration, for a release.
+type Instrument func(pitch, velocity uint8, duration x) LimitedSignal
+
+// DefaultInstrument is a Sine of the pitch, with an ADSREnvelope, released over 100ms after the duration, at a level in proportion to the velocity.
+func DefaultInstrument(pitch, velocity uint8, duration x) LimitedSignal {
+	attack, decay := Ms(5), Ms(50)
+	if duration < attack+decay {
+		if duration < Ms(1) {
+			duration = Ms(1)
+		}
+		attack, decay = duration/11, duration-duration/11
+	}
+	return Modulated{
+		Sine{MIDIPitch(pitch)},
+		NewADSREnvelope(attack, decay, duration-attack-decay, Percent(70), Ms(100)),
+		Constant{floatY(float64(velocity) / 127)},
+	}
+}
+
+// MIDIPitch returns the x of one cycle of a MIDI note number, in equal temperament, with note 69 at 440Hz.
+func MIDIPitch(pitch uint8) x {
+	return Hz(440 * math.Pow(2, (float64(pitch)-69)/12))
+}
+
+// LoadMIDI reads a Standard MIDI File, at exactly the path given, as ReadMIDI.
+func LoadMIDI(pathTo string, instrument Instrument) (Timeline, error) {
+	file, err := os.Open(pathTo)
+	if err != nil {
+		return Timeline{}, err
+	}
+	defer file.Close()
+	return ReadMIDI(file, instrument)
+}
+
+// ReadMIDI reads a Standard MIDI File, (format 0 or 1), returning a Timeline with an Event for each note, of the LimitedSignal from an Instrument, (DefaultInstrument if nil), starting at the note's time.
+// notes are timed with the file's tempo changes, (from any track), and are the same whatever their channel or program.
+// Events have a Gain of one over the most that sound at once, so the Timeline's values can't exceed unitY.
+func ReadMIDI(r io.Reader, instrument Instrument) (Timeline, error) {
+	if instrument == nil {
+		instrument = DefaultInstrument
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Timeline{}, err
+	}
+	id, header, data, err := midiChunk(data)
+	if err != nil {
+		return Timeline{}, err
+	}
+	if id != "MThd" || len(header) < 6 {
+		return Timeline{}, errors.New("Not MIDI format.")
+	}
+	format, tracks, division := binary.BigEndian.Uint16(header), binary.BigEndian.Uint16(header[2:]), binary.BigEndian.Uint16(header[4:])
+	if format > 1 {
+		return Timeline{}, errors.New(fmt.Sprintf("Unsupported MIDI format (%d).", format))
+	}
+	if division == 0 {
+		return Timeline{}, errors.New("MIDI division of zero.")
+	}
+	if division&0x8000 != 0 {
+		switch fps := -int(int8(division >> 8)); fps {
+		case 24, 25, 29, 30:
+		default:
+			return Timeline{}, errors.New(fmt.Sprintf("Unsupported MIDI SMPTE frames per second (%d).", fps))
+		}
+		if division&0xff == 0 {
+			return Timeline{}, errors.New("MIDI SMPTE division of zero ticks per frame.")
+		}
+	}
+	var notes []midiNote
+	var tempos []midiTempo
+	for track := 0; track < int(tracks); track++ {
+		var chunk []byte
+		for id != "MTrk" {
+			if len(data) == 0 {
+				return Timeline{}, errors.New(fmt.Sprintf("MIDI track %d missing.", track))
+			}
+			if id, chunk, data, err = midiChunk(data); err != nil {
+				return Timeline{}, err
+			}
+		}
+		id = ""
+		tn, tt, err := readMIDITrack(chunk)
+		if err != nil {
+			return Timeline{}, errors.New(fmt.Sprintf("MIDI track %d: %s", track, err.Error()))
+		}
+		notes, tempos = append(notes, tn...), append(tempos, tt...)
+	}
+	seconds := midiClock(division, tempos)
+	events := make([]Event, len(notes))
+	for i, n := range notes {
+		start := seconds(n.on)
+		events[i] = NewEvent(Seconds(start), instrument(n.pitch, n.velocity, Seconds(seconds(n.off)-start)))
+	}
+	if voices := mostOverlapping(events); voices > 1 {
+		for i := range events {
+			events[i].Gain = unitY / y(voices)
+		}
+	}
+	return NewTimeline(events...), nil
+}
+
+// a note, between ticks from the start of its track.
+type midiNote struct {
+	on, off         uint64
+	pitch, velocity uint8
+}
+
+// microseconds per quarter note, from a tick.
+type midiTempo struct {
+	tick         uint64
+	microseconds uint32
+}
+
+// a chunk's id and data, and the data after it.
+func midiChunk(data []byte) (id string, chunk, rest []byte, err error) {
+	if len(data) < 8 {
+		return "", nil, nil, errors.New("MIDI chunk header ran out.")
+	}
+	l := binary.BigEndian.Uint32(data[4:])
+	if uint64(l) > uint64(len(data)-8) {
+		return "", nil, nil, errors.New(fmt.Sprintf("MIDI %q chunk ran out, %d of %d bytes.", data[:4], len(data)-8, l))
+	}
+	return string(data[:4]), data[8 : 8+l], data[8+l:], nil
+}
+
+// the notes and tempo changes in a track, notes still on at its end end there.
+func readMIDITrack(data []byte) (notes []midiNote, tempos []midiTempo, err error) {
+	var tick uint64
+	var status byte
+	type key struct{ channel, pitch byte }
+	on := map[key][]midiNote{}
+	off := func(k key) {
+		if ns := on[k]; len(ns) > 0 {
+			n := ns[0]
+			n.off = tick
+			notes = append(notes, n)
+			on[k] = ns[1:]
+		}
+	}
+	p := 0
+	next := func() (byte, error) {
+		if p >= len(data) {
+			return 0, errors.New("ran out.")
+		}
+		p++
+		return data[p-1], nil
+	}
+	length := func() (uint64, error) {
+		var l uint64
+		for i := 0; i < 4; i++ {
+			b, err := next()
+			if err != nil {
+				return 0, err
+			}
+			l = l<<7 | uint64(b&0x7f)
+			if b&0x80 == 0 {
+				return l, nil
+			}
+		}
+		return 0, errors.New("variable length quantity too long.")
+	}
+	skip := func(n uint64) error {
+		if n > uint64(len(data)-p) {
+			return errors.New("ran out.")
+		}
+		p += int(n)
+		return nil
+	}
+	for p < len(data) {
+		delta, err := length()
+		if err != nil {
+			return nil, nil, err
+		}
+		tick += delta
+		b, err := next()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case b == 0xff:
+			t, err := next()
+			if err != nil {
+				return nil, nil, err
+			}
+			l, err := length()
+			if err != nil {
+				return nil, nil, err
+			}
+			if t == 0x51 && l == 3 && p+3 <= len(data) {
+				tempos = append(tempos, midiTempo{tick, uint32(data[p])<<16 | uint32(data[p+1])<<8 | uint32(data[p+2])})
+			}
+			if err = skip(l); err != nil {
+				return nil, nil, err
+			}
+			if t == 0x2f {
+				p = len(data)
+			}
+			// meta events, and sysex, cancel running status.
+			status = 0
+			continue
+		case b == 0xf0 || b == 0xf7:
+			l, err := length()
+			if err != nil {
+				return nil, nil, err
+			}
+			if err = skip(l); err != nil {
+				return nil, nil, err
+			}
+			status = 0
+			continue
+		case b&0x80 != 0:
+			status = b
+			if b, err = next(); err != nil {
+				return nil, nil, err
+			}
+		case status == 0:
+			return nil, nil, errors.New("running status without a status.")
+		}
+		// b is the first data byte, of a channel message with status.
+		var d2 byte
+		if kind := status & 0xf0; kind != 0xc0 && kind != 0xd0 {
+			if d2, err = next(); err != nil {
+				return nil, nil, err
+			}
+		}
+		k := key{status & 0x0f, b}
+		switch status & 0xf0 {
+		case 0x90:
+			if d2 > 0 {
+				on[k] = append(on[k], midiNote{on: tick, pitch: b, velocity: d2})
+				break
+			}
+			off(k)
+		case 0x80:
+			off(k)
+		}
+	}
+	for k := range on {
+		for len(on[k]) > 0 {
+			off(k)
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool { return notes[i].on < notes[j].on })
+	return
+}
+
+// a func returning the seconds at a tick, from the division, (ticks per quarter note, or, if negative, SMPTE frames per second and ticks per frame), and tempo changes.
+func midiClock(division uint16, tempos []midiTempo) func(uint64) float64 {
+	if division&0x8000 != 0 {
+		fps := float64(-int8(division >> 8))
+		if fps == 29 {
+			fps = 29.97
+		}
+		perTick := 1 / (fps * float64(division&0xff))
+		return func(t uint64) float64 { return float64(t) * perTick }
+	}
+	sort.SliceStable(tempos, func(i, j int) bool { return tempos[i].tick < tempos[j].tick })
+	// the seconds at each tempo change, and the default tempo, 120 beats per minute, from zero.
+	tempos = append([]midiTempo{{0, 500000}}, tempos...)
+	starts := make([]float64, len(tempos))
+	for i := 1; i < len(tempos); i++ {
+		starts[i] = starts[i-1] + float64(tempos[i].tick-tempos[i-1].tick)*float64(tempos[i-1].microseconds)/1e6/float64(division)
+	}
+	return func(t uint64) float64 {
+		i := sort.Search(len(tempos), func(i int) bool { return tempos[i].tick > t }) - 1
+		return starts[i] + float64(t-tempos[i].tick)*float64(tempos[i].microseconds)/1e6/float64(division)
+	}
+}
+
+// the most Events that are sounding, (from Start to MaxX), at the same time.
+func mostOverlapping(es []Event) (most int) {
+	type change struct {
+		at    x
+		delta int
+	}
+	changes := make([]change, 0, len(es)*2)
+	for _, e := range es {
+		changes = append(changes, change{e.Start, 1}, change{e.MaxX(), -1})
+	}
+	// ends before starts at the same x.
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].at < changes[j].at || changes[i].at == changes[j].at && changes[i].delta < changes[j].delta
+	})
+	var n int
+	for _, c := range changes {
+		if n += c.delta; n > most {
+			most = n
+		}
+	}
+	return
+}
@@ -0,0 +1,152 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// a Standard MIDI File, of a format, division and tracks, (the bytes of their events.)
+func smf(format, division uint16, tracks ...[]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("MThd")
+	binary.Write(&b, binary.BigEndian, []uint32{6})
+	binary.Write(&b, binary.BigEndian, []uint16{format, uint16(len(tracks)), division})
+	for _, t := range tracks {
+		b.WriteString("MTrk")
+		binary.Write(&b, binary.BigEndian, uint32(len(t)))
+		b.Write(t)
+	}
+	return b.Bytes()
+}
+
+var endOfTrack = []byte{0x00, 0xff, 0x2f, 0x00}
+
+func ExampleReadMIDI() {
+	// middle C, then E, a quarter note each, at the default tempo, (120 beats per minute.)
+	tune := smf(0, 480, append([]byte{
+		0x00, 0x90, 60, 100,
+		0x83, 0x60, 0x80, 60, 0,
+		0x00, 0x90, 64, 100,
+		0x83, 0x60, 0x80, 64, 0,
+	}, endOfTrack...))
+	t, err := ReadMIDI(bytes.NewReader(tune), nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, e := range t.Events() {
+		fmt.Printf("%.1fms\n", e.Start.Ms())
+	}
+	fmt.Printf("%.1fms\n", t.MaxX().Ms())
+	// Output:
+	// 0.0ms
+	// 500.0ms
+	// 1100.0ms
+}
+
+func TestMIDIRead(t *testing.T) {
+	var durations []x
+	var velocities []uint8
+	instrument := func(pitch, velocity uint8, duration x) LimitedSignal {
+		durations = append(durations, duration)
+		velocities = append(velocities, velocity)
+		return Modulated{Sine{MIDIPitch(pitch)}, Pulse{duration}}
+	}
+	// format 1, tempo in the first track, notes, overlapping, in the second, with running status, a note on of velocity zero as an off, and other messages.
+	tune := smf(1, 96,
+		append([]byte{
+			0x00, 0xff, 0x51, 0x03, 0x0f, 0x42, 0x40, // 1s per quarter note
+			0x60, 0xff, 0x51, 0x03, 0x07, 0xa1, 0x20, // 0.5s, after a quarter note
+		}, endOfTrack...),
+		append([]byte{
+			0x00, 0xff, 0x03, 0x04, 'l', 'e', 'a', 'd', // name
+			0x00, 0xc0, 0x05, // program change
+			0x00, 0x90, 69, 64,
+			0x30, 72, 127, // running status, half way through the first quarter
+			0x00, 0xb0, 0x07, 0x64, // control change
+			0x30, 0x90, 69, 0, // off, at the end of the first quarter
+			0x00, 0xf0, 0x02, 0x01, 0xf7, // sysex
+			0x60, 0x80, 72, 0x40, // off, a quarter later
+		}, endOfTrack...),
+	)
+	tl, err := ReadMIDI(bytes.NewReader(tune), instrument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := tl.Events()
+	if len(es) != 2 {
+		t.Fatal(es)
+	}
+	near := func(a, b x) bool { return math.Abs(a.Seconds()-b.Seconds()) < 1e-6 }
+	if !near(es[0].Start, 0) || !near(es[1].Start, Ms(500)) || !near(durations[0], Ms(1000)) || !near(durations[1], Ms(1000)) {
+		t.Error(es[0].Start, es[1].Start, durations)
+	}
+	if velocities[0] != 64 || velocities[1] != 127 {
+		t.Error(velocities)
+	}
+	// two at once, so each at half.
+	if es[0].Gain != unitY/2 || es[1].Gain != unitY/2 {
+		t.Error(es[0].Gain, es[1].Gain)
+	}
+	if !near(tl.MaxX(), Ms(1500)) {
+		t.Error(tl.MaxX())
+	}
+	// SMPTE division, 25 frames per second, 40 ticks per frame, so a ms per tick, and a note left on, ending with its track.
+	tl, err = ReadMIDI(bytes.NewReader(smf(0, uint16(0xe7)<<8|40, []byte{0x00, 0x90, 60, 100, 0x64, 0xff, 0x2f, 0x00})), instrument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es := tl.Events(); len(es) != 1 || !near(durations[2], Ms(100)) {
+		t.Error(es, durations)
+	}
+}
+
+func TestMIDIReadErrors(t *testing.T) {
+	for _, b := range [][]byte{
+		[]byte("RIFF\x00\x00\x00\x06\x00\x00\x00\x01\x00\x60"),
+		smf(2, 96, endOfTrack),
+		smf(0, 0, endOfTrack),
+		// SMPTE, of zero ticks per frame, -128 and 23 frames per second.
+		smf(0, 0xe700, endOfTrack),
+		smf(0, 0x8028, endOfTrack),
+		smf(0, 0xe928, endOfTrack),
+		// running status after sysex, and after a meta event.
+		smf(0, 96, []byte{0x00, 0x90, 60, 100, 0x00, 0xf0, 0x01, 0xf7, 0x10, 60, 0}),
+		smf(0, 96, []byte{0x00, 0x90, 60, 100, 0x00, 0xff, 0x01, 0x00, 0x10, 60, 0}),
+		smf(0, 96, []byte{0x00, 0x90, 60}),
+		smf(0, 96, []byte{0x00, 60, 100}),
+		smf(0, 96, []byte{0xff, 0xff, 0xff, 0xff, 0x7f}),
+		smf(0, 96, endOfTrack)[:14],
+		smf(0, 96, endOfTrack)[:20],
+	} {
+		if _, err := ReadMIDI(bytes.NewReader(b), nil); err == nil {
+			t.Errorf("no error reading % x", b)
+		}
+	}
+}
+
+func TestMIDIDefaultInstrument(t *testing.T) {
+	if p := MIDIPitch(69).Hz(); math.Abs(p-440) > 1e-3 {
+		t.Error(p)
+	}
+	if p := MIDIPitch(81).Hz(); math.Abs(p-880) > 1e-3 {
+		t.Error(p)
+	}
+	for _, d := range []x{0, Ms(10), Ms(500)} {
+		s := DefaultInstrument(69, 127, d)
+		if s.MaxX() < d+Ms(100) {
+			t.Error(d, s.MaxX())
+		}
+		if s.property(s.MaxX()+Ms(1)) != 0 {
+			t.Error(d)
+		}
+	}
+	loud, quiet := DefaultInstrument(69, 127, Ms(500)), DefaultInstrument(69, 32, Ms(500))
+	p := Ms(100) + MIDIPitch(69)/4
+	if l, q := loud.property(p), quiet.property(p); q <= 0 || q >= l {
+		t.Error(l, q)
+	}
+}
@@ -50,12 +50,20 @@ func (s Compressed) property(p x) y {
 	return s.Signal.property(x(float32(p) * s.Factor))
 }
 
+// the MaxX of the Signal, compressed, (0 if it isn't a LimitedSignal.)
 func (s Compressed) MaxX() x {
-	return x(float32(s.Signal.(LimitedSignal).MaxX())/s.Factor)
+	if ls, ok := s.Signal.(LimitedSignal); ok && s.Factor > 0 {
+		return scaleX64(ls.MaxX(), 1/float64(s.Factor))
+	}
+	return 0
 }
 
+// the Period of the Signal, compressed, (0 if it's aperiodic.)
 func (s Compressed) Period() x {
-	return x(float32(s.Signal.(PeriodicSignal).Period())/s.Factor)
+	if p, ok := PeriodOf(s.Signal); ok && s.Factor > 0 {
+		return scaleX64(p, 1/float64(s.Factor))
+	}
+	return 0
 }
 
 // a PeriodicSignal that is a Signal repeated with Loop length x.
@@ -65,7 +73,7 @@ type Looped struct {
 }
 
 func (s Looped) property(p x) y {
-	return s.Signal.property(p % s.Loop)
+	return s.Signal.property(modX(p, s.Loop))
 }
 
 func (s Looped) Period() x {
@@ -79,12 +87,29 @@ type Repeated struct {
 	Cycles float32
 }
 
+// Cycles of the PeriodicSignal's Period, (0 if it's aperiodic.)
 func (s Repeated) Period() x {
-	return x(float32(s.PeriodicSignal.Period()) * s.Cycles)
+	if p, ok := PeriodOf(s.PeriodicSignal); ok && s.Cycles > 0 {
+		return scaleX64(p, float64(s.Cycles))
+	}
+	return 0
 }
 
+// NewRepeated returns a Repeated with the PeriodicSignal's Period found once, (as a Looped), rather than for every value, which, for one made from others, finds their common period.
+func NewRepeated(s PeriodicSignal, cycles float32) Repeated {
+	if p, ok := PeriodOf(s); ok {
+		return Repeated{Looped{s, p}, cycles}
+	}
+	return Repeated{s, cycles}
+}
+
+// an aperiodic PeriodicSignal, (Period zero), isn't repeated.
 func (s Repeated) property(p x) y {
-	return s.PeriodicSignal.property((p % s.Period()) % s.PeriodicSignal.Period())
+	period, ok := PeriodOf(s.PeriodicSignal)
+	if !ok || s.Cycles <= 0 {
+		return s.PeriodicSignal.property(p)
+	}
+	return s.PeriodicSignal.property(modX(modX(p, scaleX64(period, float64(s.Cycles))), period))
 }
 
 
@@ -130,21 +155,9 @@ func (s RateModulated) property(p x) y {
 	return s.Signal.property(p + MultiplyX(float64(s.Modulation.property(p))/unitYfloat64, s.Factor))
 }
 
+// the common period of the Signal and the Modulation, (0 if either is aperiodic, see commonPeriod.)
 func (s RateModulated) Period() x {
-	if  mps,ok:= s.Modulation.(PeriodicSignal);ok {
-		if  ps,ok:= s.Signal.(PeriodicSignal);ok {
-			if mpsp,psp:=mps.Period(),ps.Period(); mpsp>psp {
-				return mpsp
-			}else{ 
-				return psp
-			}	
-		}else{
-			return mps.Period()
-		}
-	}else{
-		s.Signal.(PeriodicSignal).Period()
-	}
-	return 0
+	return commonPeriod(s.Signal, s.Modulation)
 }
 
 
@@ -157,27 +170,25 @@ type Segmented struct {
 }
 
 type endInfo struct {
+	mutex          sync.Mutex // per Segmented, shared by its copies.
 	x1, x2, l1, l2 x
 }
 
-
-var segmentedMutex = &sync.Mutex{}
-
 func NewSegmented(s Signal, width x) Segmented {
 	return Segmented{s, width, &endInfo{}}
 }
 
 func (s Segmented) property(p x) (value y) {
-	temp := p % s.Width
-	segmentedMutex.Lock()
-	if p-temp != s.ends.x1 || p+s.Width-temp != s.ends.x2 {
+	temp := modX(p, s.Width)
+	s.ends.mutex.Lock()
+	if p-temp != s.ends.x1 || s.ends.x1+s.Width != s.ends.x2 {
 		s.ends.x1 = p - temp
-		s.ends.x2 = p + s.Width - temp
+		s.ends.x2 = s.ends.x1 + s.Width
 		s.ends.l1 = x(s.Signal.property(s.ends.x1)) 
 		s.ends.l2 = x(s.Signal.property(s.ends.x2))/s.Width - s.ends.l1/ s.Width
 	}
 	value=y(s.ends.l1 + s.ends.l2*temp)
-	segmentedMutex.Unlock()
+	s.ends.mutex.Unlock()
 	return
 }
 
@@ -203,6 +214,7 @@ type searchInfo struct {
 	Shift   x
 	trigger y
 	rising  bool
+	mutex   sync.Mutex // per Triggered, shared by its copies.
 }
 
 func NewTriggered(s Signal, trigger y, rising bool, res, max x) Triggered {
@@ -210,6 +222,8 @@ func NewTriggered(s Signal, trigger y, rising bool, res, max x) Triggered {
 }
 
 func (s Triggered) property(p x) y {
+	s.Found.mutex.Lock()
+	defer s.Found.mutex.Unlock()
 	if s.Trigger != s.Found.trigger || s.Found.rising != s.Rising {
 		s.Found.trigger = s.Trigger
 		s.Found.rising = s.Rising
@@ -0,0 +1,24 @@
+package signals
+
+import "testing"
+
+func TestCompressedMaxX(t *testing.T) {
+	for _, c := range []struct {
+		Compressed
+		max x
+	}{
+		{Compressed{Pulse{unitX}, 2}, unitX / 2},
+		{Compressed{Pulse{unitX}, 0.5}, unitX * 2},
+		// more than float32 precision.
+		{Compressed{Pulse{unitX * 1000}, 1}, unitX * 1000},
+		{Compressed{Pulse{unitX*1000 + 1}, 1}, unitX*1000 + 1},
+		// not limited, or not compressed to a length.
+		{Compressed{Sine{unitX}, 2}, 0},
+		{Compressed{Pulse{unitX}, 0}, 0},
+		{Compressed{Pulse{unitX}, -1}, 0},
+	} {
+		if m := c.Compressed.MaxX(); m != c.max {
+			t.Error(c.Compressed, m, c.max)
+		}
+	}
+}
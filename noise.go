@@ -25,10 +25,10 @@ var noiseMutex = &sync.Mutex{}
 
 func (s Noise) property(p x) (v y) {
 	noiseMutex.Lock()
-	rand.Seed(int64(p))                   // set the default generators seed to the same, for the same x
+	rand.Seed(seedX(p))                   // set the default generators seed to the same, for the same x
 	s.generator.Seed(int64(rand.Int63())) // a Noise sets its generator's seed to a random number from the default generator, which will be the same for the same x, and so the same random numbers will be generated from it, but will be different for different Noises.
-	v = y(s.generator.Int63())
-	v -= y(s.generator.Int63())
+	v = fromFullScale(s.generator.Int63())
+	v -= fromFullScale(s.generator.Int63())
 	noiseMutex.Unlock()
 	return
 }
@@ -0,0 +1,204 @@
+package signals
+
+// Optimize returns a Signal, with the same values, that's cheaper to evaluate, rewriting the built-in types in a composition.
+// Constants are folded, Shifted's, Offset's and Compressed's of the same merged, Modulated's and Composite's in their own kind flattened, and identities removed, (for example; Modulated members of Constant{unitY}, Shifted's of zero and Inverted's of Inverted's.)
+// the result keeps MaxX and Period, a rewrite that would change them, of it or anything it's in, isn't made.
+// values can differ, by rounding, where a Compressed with a Factor of one is removed, or merged, and where Constants are folded together.
+// Signals not of the built-in types are left as they are, and so are their members.
+func Optimize(s Signal) Signal {
+	o, _ := optimize(s)
+	return o
+}
+
+// optimize returns a Signal's rewrite, and if it has been rewritten, so that unchanged Signals, with state, aren't made again.
+func optimize(s Signal) (Signal, bool) {
+	name, values, err := describe(s)
+	if err != nil {
+		return s, false
+	}
+	st := signalTypes[name]
+	changed := false
+	for i, v := range values {
+		if m, ok := v.(Signal); ok {
+			if o, ok := optimize(m); ok && st.parameter(i).kind.check(o) == nil {
+				values[i] = o
+				if r, err := makeSignal(name, values); err == nil && keeps(s, r) {
+					changed = true
+					continue
+				}
+				values[i] = m
+			}
+		}
+	}
+	if changed {
+		s, _ = makeSignal(name, values)
+	}
+	if r, ok := rewrite(s); ok && keeps(s, r) {
+		o, _ := optimize(r)
+		return o, true
+	}
+	return s, changed
+}
+
+// one rewrite of a Signal, if there's one.
+func rewrite(s Signal) (Signal, bool) {
+	switch st := s.(type) {
+	case Shifted:
+		switch m := st.Signal.(type) {
+		case Shifted:
+			return Shifted{m.Signal, m.Shift + st.Shift}, true
+		case Constant:
+			return m, true
+		}
+		if st.Shift == 0 {
+			return st.Signal, true
+		}
+	case Offset:
+		if m, ok := st.LimitedSignal.(Offset); ok {
+			return Offset{m.LimitedSignal, m.Offset + st.Offset}, true
+		}
+		if st.Offset == 0 {
+			return st.LimitedSignal, true
+		}
+	case Compressed:
+		switch m := st.Signal.(type) {
+		case Compressed:
+			return Compressed{m.Signal, m.Factor * st.Factor}, true
+		case Constant:
+			return m, true
+		}
+		if st.Factor == 1 {
+			return st.Signal, true
+		}
+	case Inverted:
+		switch m := st.Signal.(type) {
+		case Inverted:
+			return m.Signal, true
+		case Constant:
+			return Constant{-m.Constant}, true
+		}
+	case Reversed:
+		switch m := st.Signal.(type) {
+		case Reversed:
+			return m.Signal, true
+		case Constant:
+			return m, true
+		}
+	case Reflected:
+		if _, ok := st.Signal.(Constant); ok {
+			return Constant{st.property(0)}, true
+		}
+	case RateModulated:
+		if m, ok := st.Signal.(Constant); ok {
+			return m, true
+		}
+		if m, ok := st.Modulation.(Constant); ok && (m.Constant == 0 || st.Factor == 0) {
+			return st.Signal, true
+		}
+	case Cached:
+		if m, ok := st.Signal.(Constant); ok {
+			return m, true
+		}
+	case Buffered:
+		if m, ok := st.Signal.(Constant); ok {
+			return m, true
+		}
+	case Modulated:
+		return rewriteMembers(st, func(ss []Signal) Signal { return Modulated(ss) }, unitY, multiplyY)
+	case Composite:
+		return rewriteMembers(st, func(ss []Signal) Signal { return Composite(ss) }, 0, func(a, b y) y { return a + b })
+	case Stacked:
+		switch len(st) {
+		case 0:
+			return Constant{0}, true
+		case 1:
+			return st[0], true
+		}
+	case Sequenced:
+		for i, m := range st {
+			if n, ok := m.(Sequenced); ok {
+				return append(append(append(Sequenced{}, st[:i]...), n...), st[i+1:]...), true
+			}
+		}
+	}
+	return s, false
+}
+
+// rewrite a Modulated or Composite, flattening members of the same type, folding its Constants into a first member, and removing it if it's the identity.
+func rewriteMembers(ss []Signal, combine func([]Signal) Signal, identity y, fold func(y, y) y) (Signal, bool) {
+	self := combine(nil)
+	members := make([]Signal, 0, len(ss))
+	var constants int
+	var folded y
+	changed := false
+	for _, m := range ss {
+		if c, ok := m.(Constant); ok {
+			if c.Constant == identity {
+				changed = true
+				continue
+			}
+			if constants == 0 {
+				folded = c.Constant
+			} else {
+				folded = fold(folded, c.Constant)
+			}
+			constants++
+			continue
+		}
+		if n, ok := sameCombiner(self, m); ok {
+			members = append(members, n...)
+			changed = true
+			continue
+		}
+		members = append(members, m)
+	}
+	changed = changed || constants > 1 || constants == 1 && ss[0] != Signal(Constant{folded})
+	if constants > 0 && folded != identity {
+		members = append([]Signal{Constant{folded}}, members...)
+	}
+	switch len(members) {
+	case 0:
+		return Constant{identity}, true
+	case 1:
+		return members[0], true
+	}
+	return combine(members), changed
+}
+
+// the members of a Signal, if it is of the same combiner type as another.
+func sameCombiner(c, s Signal) ([]Signal, bool) {
+	switch c.(type) {
+	case Modulated:
+		m, ok := s.(Modulated)
+		return m, ok
+	case Composite:
+		m, ok := s.(Composite)
+		return m, ok
+	}
+	return nil, false
+}
+
+// whether a replacement has the MaxX and Period of the Signal it replaces, when that has them.
+func keeps(s, r Signal) bool {
+	if m := limit(s); m != 0 && limit(r) != m {
+		return false
+	}
+	if p := period(s); p != 0 && period(r) != p {
+		return false
+	}
+	return true
+}
+
+// the MaxX of a Signal, zero if not a LimitedSignal.
+func limit(s Signal) x {
+	if ls, ok := s.(LimitedSignal); ok {
+		return ls.MaxX()
+	}
+	return 0
+}
+
+// the Period of a Signal, zero if aperiodic.
+func period(s Signal) x {
+	p, _ := PeriodOf(s)
+	return p
+}
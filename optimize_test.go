@@ -0,0 +1,83 @@
+package signals
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleOptimize() {
+	s := Modulated{Shifted{Shifted{Sine{Hz(100)}, Ms(1)}, Ms(2)}, Constant{unitY}}
+	text, _ := FormatSignal(Optimize(s))
+	fmt.Println(text)
+	// Output:
+	// Shifted(Sine(100Hz), 0.003)
+}
+
+// sampled values of two Signals are the same, to within a relative error.
+func sameSampled(t *testing.T, s, o Signal, start, end, step x, tolerance float64) {
+	for i := x(0); start+i*step < end; i++ {
+		p := start + i*step
+		if e := float64(s.property(p)-o.property(p)) / float64(unitY); e > tolerance || e < -tolerance {
+			t.Errorf("%v at %v: %v not %v", o, p, o.property(p), s.property(p))
+			return
+		}
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	noise := NewNoise()
+	for _, c := range []struct {
+		s, o Signal
+	}{
+		{Modulated{Sine{Ms(1)}, Constant{unitY}}, Sine{Ms(1)}},
+		{Modulated{Constant{unitY / 2}, Sine{Ms(1)}, Constant{unitY}}, Modulated{Constant{unitY / 2}, Sine{Ms(1)}}},
+		{Modulated{Sine{Ms(1)}, Constant{0}, Sine{Ms(3)}}, Modulated{Constant{0}, Sine{Ms(1)}, Sine{Ms(3)}}},
+		{Modulated{Modulated{Sine{Ms(1)}, Sine{Ms(2)}}, Sine{Ms(3)}}, Modulated{Sine{Ms(1)}, Sine{Ms(2)}, Sine{Ms(3)}}},
+		{Modulated{}, Constant{unitY}},
+		{Composite{}, Constant{0}},
+		{Composite{Sine{Ms(1)}, Composite{Constant{unitY / 4}, Sine{Ms(2)}}, Constant{unitY / 4}}, Composite{Constant{unitY/4 + unitY/4}, Sine{Ms(1)}, Sine{Ms(2)}}},
+		{Composite{Constant{0}, Pulse{Ms(5)}}, Pulse{Ms(5)}},
+		{Stacked{Sine{Ms(1)}}, Sine{Ms(1)}},
+		{Shifted{Shifted{Sine{Ms(1)}, Ms(1)}, -Ms(1)}, Sine{Ms(1)}},
+		{Shifted{Constant{unitY / 2}, Ms(1)}, Constant{unitY / 2}},
+		{Offset{Offset{Pulse{Ms(5)}, Ms(1)}, Ms(2)}, Offset{Pulse{Ms(5)}, Ms(3)}},
+		{Compressed{Compressed{Sine{Ms(1)}, 2}, 0.5}, Sine{Ms(1)}},
+		{Inverted{Inverted{noise}}, noise},
+		{Inverted{Constant{unitY / 2}}, Constant{-unitY / 2}},
+		{Reversed{Reversed{Sine{Ms(1)}}}, Sine{Ms(1)}},
+		{Reflected{Constant{unitY / 4}}, Constant{unitY - unitY/4}},
+		{RateModulated{Sine{Ms(1)}, Constant{0}, Ms(1)}, Sine{Ms(1)}},
+		{Sequenced{Pulse{Ms(1)}, Sequenced{Pulse{Ms(2)}, Pulse{Ms(3)}}}, Sequenced{Pulse{Ms(1)}, Pulse{Ms(2)}, Pulse{Ms(3)}}},
+		{Looped{Sine{Ms(1)}, Ms(2)}, Looped{Sine{Ms(1)}, Ms(2)}},
+		// nested in modifiers that aren't changed.
+		{Looped{Modulated{Pulse{Ms(1)}, Constant{unitY}}, Ms(2)}, Looped{Pulse{Ms(1)}, Ms(2)}},
+		// would lose MaxX or Period.
+		{Modulated{Pulse{Ms(1)}, Constant{0}}, Modulated{Constant{0}, Pulse{Ms(1)}}},
+		{Looped{Constant{unitY}, Ms(2)}, Looped{Constant{unitY}, Ms(2)}},
+		{Shifted{Looped{Sine{Ms(1)}, Ms(2)}, 0}, Looped{Sine{Ms(1)}, Ms(2)}},
+	} {
+		o := Optimize(c.s)
+		if !reflect.DeepEqual(o, c.o) {
+			t.Errorf("%#v: %#v not %#v", c.s, o, c.o)
+		}
+		sameSampled(t, c.s, o, -Ms(10), Ms(10), Ms(1)/7, 1e-4)
+	}
+}
+
+// Optimize of the example compositions gives the same values.
+func TestOptimizeSampled(t *testing.T) {
+	tone := Modulated{Sine{Hz(440)}, NewConstant(-6), Constant{unitY}}
+	for _, s := range []Signal{
+		Modulated{Looped{Modulated{Pulse{unitX * 2}, Constant{unitY}}, unitX * 6}, Composite{Sine{Hz(440)}, Sine{Hz(480)}}},
+		Composite{Shifted{Shifted{tone, Ms(3)}, Ms(4)}, Inverted{Inverted{Offset{Pulse{Ms(50)}, Ms(20)}}}, Constant{unitY / 8}, Constant{-unitY / 8}},
+		Stacked{Compressed{Compressed{tone, 2}, 1.5}, Compressed{Sine{Hz(100)}, 1}},
+		Modulated{Modulated{tone, NewADSREnvelope(Ms(10), Ms(20), Ms(30), unitY/2, Ms(40))}, Modulated{Reversed{Reversed{Sine{Hz(50)}}}}},
+	} {
+		o := Optimize(s)
+		sameSampled(t, s, o, 0, unitX/5, unitX/44100, 1e-4)
+		if !keeps(s, o) {
+			t.Error(s, o, limit(o), period(o))
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package signals
+
+import (
+	"math"
+)
+
+// the relative tolerance for periods to be taken as rational multiples of each other, when finding the Period of Signals made from others.
+const PeriodTolerance = 1e-6
+
+// largest whole number of any period in a common period.
+const maxMultiple = 1 << 32
+
+// PeriodOf returns the Period of a Signal, and false if it's aperiodic.
+// Signals that aren't PeriodicSignals, and PeriodicSignals with a Period of zero, (as those made from aperiodic Signals have), are aperiodic.
+// a Shifted Signal, (not itself a PeriodicSignal, since what it shifts mightn't be), has the Period of the Signal.
+func PeriodOf(s Signal) (period x, periodic bool) {
+	if ss, ok := s.(Shifted); ok {
+		return PeriodOf(ss.Signal)
+	}
+	if ps, ok := s.(PeriodicSignal); ok {
+		if period = ps.Period(); period > 0 {
+			return period, true
+		}
+	}
+	return 0, false
+}
+
+// CommonPeriod returns the shortest x that is a whole number of each of the periods, their least common multiple, and false if there isn't one.
+// periods within a relative tolerance, (plus x's precision), of a rational multiple of each other are taken to be it, so, for example, the periods of 450Hz and 350Hz, which aren't exact x's, have a common period of 1/50s.
+// when several x's are within precision of the multiple, the one that's the shortest decimal of seconds is returned, when none are, (only near rational), the multiple of the first period.
+// the same periods have their period, and there isn't one for no periods, any period not positive, or a multiple out of range.
+func CommonPeriod(tolerance float64, periods ...x) (x, bool) {
+	if len(periods) == 0 || periods[0] <= 0 {
+		return 0, false
+	}
+	p0 := periods[0]
+	same := true
+	numerators := make([]int64, len(periods))
+	denominators := make([]int64, len(periods))
+	var multiple int64 = 1 // of p0
+	for i, p := range periods {
+		if p <= 0 {
+			return 0, false
+		}
+		n, d, ok := rational(float64(p)/float64(p0), tolerance+precisionX(p)+precisionX(p0))
+		if !ok {
+			return 0, false
+		}
+		g := gcd(multiple, n)
+		if multiple/g > maxMultiple/n {
+			return 0, false
+		}
+		multiple = multiple / g * n
+		numerators[i], denominators[i] = n, d
+		same = same && p == p0
+	}
+	if same {
+		return p0, true
+	}
+	// the range, in seconds, that's within precision of being a whole number of every period.
+	lo, hi := math.Inf(-1), math.Inf(1)
+	for i, p := range periods {
+		l := float64(multiple/numerators[i]) * float64(denominators[i]) * p.Seconds()
+		lo = math.Max(lo, l*(1-precisionX(p)))
+		hi = math.Min(hi, l*(1+precisionX(p)))
+	}
+	common := float64(multiple) * p0.Seconds()
+	for places := 0; places < 19 && lo <= hi; places++ {
+		scale := math.Pow10(places)
+		if c := math.Ceil(lo*scale) / scale; c <= hi {
+			common = c
+			break
+		}
+	}
+	if period := Seconds(common); period > 0 {
+		return period, true
+	}
+	return 0, false
+}
+
+// the common period of Signals combined together.
+// Constants, and LimitedSignals that aren't periodic, don't change it, any other aperiodic Signal makes it aperiodic, zero.
+func commonPeriod(ss ...Signal) x {
+	var periods []x
+	for _, s := range ss {
+		if p, ok := PeriodOf(s); ok {
+			periods = append(periods, p)
+			continue
+		}
+		if isConstant(s) {
+			continue
+		}
+		if ls, ok := s.(LimitedSignal); ok && ls.MaxX() > 0 {
+			continue
+		}
+		return 0
+	}
+	p, _ := CommonPeriod(PeriodTolerance, periods...)
+	return p
+}
+
+// a Constant, or a profiled one.
+func isConstant(s Signal) bool {
+	if p, ok := s.(interface{ unprofiled() Signal }); ok {
+		s = p.unprofiled()
+	}
+	_, ok := s.(Constant)
+	return ok
+}
+
+// the simplest fraction, from continued fractions, within a relative tolerance of a positive float, false if its numerator or denominator would be more than maxMultiple.
+func rational(r, tolerance float64) (numerator, denominator int64, ok bool) {
+	n0, d0, n1, d1 := int64(0), int64(1), int64(1), int64(0)
+	for f := r; ; f = 1 / f {
+		a := math.Floor(f)
+		if a > maxMultiple {
+			return 0, 0, false
+		}
+		n0, d0, n1, d1 = n1, d1, int64(a)*n1+n0, int64(a)*d1+d0
+		if n1 > maxMultiple || d1 > maxMultiple {
+			return 0, 0, false
+		}
+		if n1 > 0 && math.Abs(float64(n1)/float64(d1)-r) <= tolerance*r {
+			return n1, d1, true
+		}
+		if f -= a; f == 0 {
+			return 0, 0, false
+		}
+	}
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,96 @@
+package signals
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func ExampleCommonPeriod() {
+	dialTone := Stacked{Sine{Hz(350)}, Sine{Hz(440)}}
+	fmt.Println(dialTone.Period().Frequency())
+	_, periodic := PeriodOf(Stacked{Sine{Hz(350)}, NewNoise()})
+	fmt.Println(periodic)
+	// Output:
+	// 10Hz
+	// false
+}
+
+func TestPeriodCommon(t *testing.T) {
+	for _, c := range []struct {
+		s        Signal
+		period   x
+		periodic bool
+	}{
+		{Stacked{Sine{Hz(450)}, Sine{Hz(350)}}, Hz(50), true},
+		{Composite{Sine{Hz(440)}, Sine{Hz(441)}}, Seconds(1), true},
+		{Modulated{Sine{Hz(1000)}, Constant{unitY / 2}, Pulse{Ms(100)}}, Ms(1), true},
+		{Modulated{Looped{Pulse{Ms(500)}, Ms(750)}, Sine{Hz(400)}}, Ms(750), true},
+		{Stacked{Sine{Ms(2)}, Square{Ms(3)}, Sine{Ms(4)}}, Ms(12), true},
+		{Stacked{Stacked{Sine{Hz(300)}, Sine{Hz(500)}}, Sine{Hz(700)}}, Hz(100), true},
+		{Composite{Sine{Hz(1000)}, Sigmoid{unitX}}, 0, false},
+		{Stacked{Constant{unitY}}, 0, false},
+		{Stacked{}, 0, false},
+		{Stacked{&Wave{URL: testDataURL}, Sine{Ms(2)}}, 0, false}, // a Wave not yet read has no MaxX, so isn't known to be limited.
+		{Shifted{Stacked{Sine{Hz(450)}, Sine{Hz(350)}}, Ms(1)}, Hz(50), true},
+		{Shifted{Heavyside{}, Ms(1)}, 0, false},
+		{Compressed{Stacked{Sine{Hz(450)}, Sine{Hz(350)}}, 2}, Hz(100), true},
+		{Compressed{NewNoise(), 2}, 0, false},
+		{Repeated{Stacked{Sine{Ms(2)}, Sine{Ms(3)}}, 2}, Ms(12), true},
+		{Repeated{Stacked{Sine{Ms(2)}, NewNoise()}, 2}, 0, false},
+		{RateModulated{Sine{Ms(2)}, Sine{Ms(3)}, Ms(1)}, Ms(6), true},
+		{RateModulated{Sine{Ms(2)}, Constant{unitY}, Ms(1)}, Ms(2), true},
+		{RateModulated{Sine{Ms(2)}, NewNoise(), Ms(1)}, 0, false},
+	} {
+		period, periodic := PeriodOf(c.s)
+		if period != c.period || periodic != c.periodic {
+			t.Errorf("%v: %v %v not %v %v", c.s, period, periodic, c.period, c.periodic)
+		}
+	}
+}
+
+func TestPeriodCommonPeriod(t *testing.T) {
+	if p, ok := CommonPeriod(PeriodTolerance); ok || p != 0 {
+		t.Error("none", p, ok)
+	}
+	if p, ok := CommonPeriod(PeriodTolerance, Ms(2), 0); ok || p != 0 {
+		t.Error("zero", p, ok)
+	}
+	if p, ok := CommonPeriod(PeriodTolerance, Ms(3)); !ok || p != Ms(3) {
+		t.Error("single", p, ok)
+	}
+	// pi taken as 355/113
+	if p, ok := CommonPeriod(PeriodTolerance, Seconds(1), Seconds(3.141592653589793)); !ok || p < Seconds(354.99) || p > Seconds(355.01) {
+		t.Error("near rational", p, ok)
+	}
+	// but not within a tighter tolerance
+	if p, ok := CommonPeriod(0, Ms(1), Ms(1.0001)); !ok || p != Ms(10001) {
+		t.Error("exact", p, ok)
+	}
+	if p, ok := CommonPeriod(1e-3, Ms(1), Ms(1.0001)); !ok || p != Ms(1) {
+		t.Error("tolerance", p, ok)
+	}
+}
+
+func TestPeriodRepeated(t *testing.T) {
+	// of an aperiodic PeriodicSignal, not repeated.
+	r := Repeated{Modulated{Sine{Ms(1)}, Heavyside{}}, 2}
+	if r.Period() != 0 || r.property(Ms(7.25)) != (Modulated{Sine{Ms(1)}, Heavyside{}}).property(Ms(7.25)) {
+		t.Error(r.Period())
+	}
+	r = Repeated{Stacked{Sine{Hz(450)}, Sine{Hz(350)}}, 2}
+	if p := r.Period(); math.Abs(p.Seconds()-0.04) > 1e-12 || r.property(Ms(3)) != r.PeriodicSignal.property(Ms(3)) {
+		t.Error(p)
+	}
+	// made with its period found once, so the common period isn't found again for every value.
+	n := NewRepeated(r.PeriodicSignal, 2)
+	if n.Period() != r.Period() || n.property(Ms(3)) != r.property(Ms(3)) || n.property(Ms(57)) != r.property(Ms(57)) {
+		t.Error(n.Period())
+	}
+	if a := testing.AllocsPerRun(100, func() { PeriodOf(n.PeriodicSignal) }); a != 0 {
+		t.Error(a)
+	}
+	if n := NewRepeated(Modulated{Sine{Ms(1)}, Heavyside{}}, 2); n.Period() != 0 {
+		t.Error(n.Period())
+	}
+}
@@ -0,0 +1,169 @@
+package signals
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// Profile is the counts, and times, of evaluations of every Signal in a composition, made by NewProfile.
+type Profile struct {
+	root *nodeProfile
+}
+
+// NewProfile returns a copy of a composition, in which every Signal counts and times its evaluations, and the Profile that reports them.
+// the copy has the same values, and is LimitedSignal or PeriodicSignal, as each Signal in it was, but Cached, Buffered, Segmented and Triggered Signals in it start with new, empty, state.
+// Signals not of this package's types are profiled, but the Signals they're made from aren't.
+// timing adds, very roughly, 100ns to each evaluation, which is included in the times of the Signals it's in.
+func NewProfile(s Signal) (Signal, *Profile) {
+	p, n := profile(s)
+	return p, &Profile{n}
+}
+
+// NodeProfile is the evaluations of one Signal in a profiled composition.
+type NodeProfile struct {
+	Type         string        // as Node's.
+	Depth        int           // as Node's.
+	Calls        uint64        // number of evaluations.
+	Time         time.Duration // cumulative, including the Signals it's made from.
+	SelfTime     time.Duration // excluding the Signals it's made from.
+	Cache        bool          // a cache, so has Hits and Misses, (Cached and Buffered.)
+	Hits, Misses uint64
+}
+
+// HitRatio is the fraction of evaluations of a cache that used a stored value, zero if not a cache or not evaluated.
+func (n NodeProfile) HitRatio() float64 {
+	if n.Hits+n.Misses == 0 {
+		return 0
+	}
+	return float64(n.Hits) / float64(n.Hits+n.Misses)
+}
+
+// Nodes returns the NodeProfile of every Signal, in the order Walk visits them.
+func (p *Profile) Nodes() []NodeProfile {
+	return p.root.nodes(nil, 0)
+}
+
+// Reset zeros the counts and times, (not the hits and misses of caches.)
+func (p *Profile) Reset() {
+	p.root.reset()
+}
+
+// WriteReport writes a table of NodeProfiles, with Types indented by Depth.
+func (p *Profile) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "calls\ttime\tself\thits\t\tSignal")
+	for _, n := range p.Nodes() {
+		hits := ""
+		if n.Cache {
+			hits = fmt.Sprintf("%.1f%%", n.HitRatio()*100)
+		}
+		fmt.Fprintf(tw, "%d\t%v\t%v\t%s\t\t%s%s\n", n.Calls, n.Time, n.SelfTime, hits, strings.Repeat("  ", n.Depth), n.Type)
+	}
+	return tw.Flush()
+}
+
+// counts and total nanoseconds of evaluations of one Signal, updated atomically.
+type nodeProfile struct {
+	calls, nanoseconds uint64
+	typ                string
+	signal             Signal
+	children           []*nodeProfile
+}
+
+func (n *nodeProfile) nodes(ns []NodeProfile, depth int) []NodeProfile {
+	np := NodeProfile{Type: n.typ, Depth: depth, Calls: atomic.LoadUint64(&n.calls), Time: time.Duration(atomic.LoadUint64(&n.nanoseconds))}
+	np.SelfTime = np.Time
+	for _, c := range n.children {
+		np.SelfTime -= time.Duration(atomic.LoadUint64(&c.nanoseconds))
+	}
+	if c, ok := n.signal.(interface{ Stats() (hits, misses uint64) }); ok {
+		np.Cache = true
+		np.Hits, np.Misses = c.Stats()
+	}
+	ns = append(ns, np)
+	for _, c := range n.children {
+		ns = c.nodes(ns, depth+1)
+	}
+	return ns
+}
+
+func (n *nodeProfile) reset() {
+	atomic.StoreUint64(&n.calls, 0)
+	atomic.StoreUint64(&n.nanoseconds, 0)
+	for _, c := range n.children {
+		c.reset()
+	}
+}
+
+// a Signal, made again from profiled Signals, profiled, in the wrapper with the same interfaces.
+func profile(s Signal) (Signal, *nodeProfile) {
+	n := &nodeProfile{}
+	name, values, err := describe(s)
+	if err != nil {
+		n.typ = fmt.Sprintf("%T", s)
+	} else {
+		n.typ = name
+		st := signalTypes[name]
+		for i, v := range values {
+			if c, ok := v.(Signal); ok && st.parameter(i).kind.isSignal() {
+				var cn *nodeProfile
+				values[i], cn = profile(c)
+				n.children = append(n.children, cn)
+			}
+		}
+		if len(n.children) > 0 {
+			if r, err := makeSignal(name, values); err == nil {
+				s = r
+			}
+		}
+	}
+	n.signal = s
+	p := profiled{s, n}
+	_, limited := s.(LimitedSignal)
+	_, periodic := s.(PeriodicSignal)
+	switch {
+	case limited && periodic:
+		return profiledPeriodicLimited{p}, n
+	case limited:
+		return profiledLimited{p}, n
+	case periodic:
+		return profiledPeriodic{p}, n
+	}
+	return p, n
+}
+
+// a Signal counting and timing its evaluations.
+type profiled struct {
+	Signal
+	*nodeProfile
+}
+
+func (s profiled) property(p x) y {
+	start := time.Now()
+	v := s.Signal.property(p)
+	atomic.AddUint64(&s.nanoseconds, uint64(time.Since(start)))
+	atomic.AddUint64(&s.calls, 1)
+	return v
+}
+
+func (s profiled) ConcurrentSafe() bool { return IsConcurrentSafe(s.Signal) }
+
+type profiledLimited struct{ profiled }
+
+func (s profiledLimited) MaxX() x { return s.Signal.(LimitedSignal).MaxX() }
+
+type profiledPeriodic struct{ profiled }
+
+func (s profiledPeriodic) Period() x { return s.Signal.(PeriodicSignal).Period() }
+
+type profiledPeriodicLimited struct{ profiled }
+
+func (s profiledPeriodicLimited) MaxX() x   { return s.Signal.(LimitedSignal).MaxX() }
+func (s profiledPeriodicLimited) Period() x { return s.Signal.(PeriodicSignal).Period() }
+
+// the Signal profiled.
+func (s profiled) unprofiled() Signal { return s.Signal }
@@ -0,0 +1,85 @@
+package signals
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	tone := Modulated{Looped{Pulse{Ms(500)}, Ms(1000)}, Stacked{NewCached(Sine{Hz(480)}, 0, 0), Sine{Hz(620)}}, NewConstant(-6)}
+	p, profile := NewProfile(tone)
+	if _, ok := p.(PeriodicSignal); !ok {
+		t.Fatal("not periodic")
+	}
+	if p.(PeriodicSignal).Period() != tone.Period() || p.(LimitedSignal).MaxX() != tone.MaxX() {
+		t.Error(p.(PeriodicSignal).Period(), tone.Period())
+	}
+	const samples = 8000
+	profiled, err := Render(unitX-unitX/samples, samples, 2, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, _ := Render(unitX-unitX/samples, samples, 2, tone)
+	if !bytes.Equal(profiled[0].(PCM16bit).Data, rendered[0].(PCM16bit).Data) {
+		t.Error("different values.")
+	}
+	nodes := profile.Nodes()
+	if len(nodes) != 8 {
+		t.Fatal(len(nodes))
+	}
+	types := []string{"Modulated", "Looped", "Pulse", "Stacked", "Cached", "Sine", "Sine", "Constant"}
+	depths := []int{0, 1, 2, 1, 2, 3, 2, 1}
+	for i, n := range nodes {
+		if n.Type != types[i] || n.Depth != depths[i] {
+			t.Error(i, n)
+		}
+		if n.SelfTime < 0 || n.SelfTime > n.Time {
+			t.Error(i, n.SelfTime, n.Time)
+		}
+	}
+	// Modulated stops at a zero, so the Stacked, and its members, only during the pulses, (which include their end), and the Constant not when Stacked is zero, at the start.
+	if nodes[0].Calls != samples || nodes[1].Calls != samples || nodes[3].Calls != samples/2+1 || nodes[4].Calls != samples/2+1 || nodes[7].Calls != samples/2 {
+		t.Error(nodes)
+	}
+	if !nodes[4].Cache || nodes[4].Misses != samples/2+1 || nodes[4].Hits != 0 || nodes[5].Calls != samples/2+1 {
+		t.Error(nodes[4], nodes[5])
+	}
+	// the first again, twice, the cache is too small to still have it the first time.
+	Render(0, samples, 2, p, p)
+	if nodes = profile.Nodes(); nodes[4].Hits != 1 || nodes[4].HitRatio() != 1.0/(samples/2+3) {
+		t.Error(nodes[4])
+	}
+	var b bytes.Buffer
+	if err := profile.WriteReport(&b); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(b.String(), "\n"); len(lines) != 10 || !strings.HasSuffix(lines[5], "    Cached") || !strings.Contains(lines[5], "0.0%") {
+		t.Error(b.String())
+	}
+	profile.Reset()
+	if nodes = profile.Nodes(); nodes[0].Calls != 0 || nodes[0].Time != 0 {
+		t.Error(nodes[0])
+	}
+}
+
+func TestProfileInterfaces(t *testing.T) {
+	p, _ := NewProfile(Sequenced{Offset{Pulse{Ms(1)}, Ms(1)}, Pulse{Ms(2)}})
+	if p.(LimitedSignal).MaxX() != Ms(4) {
+		t.Error(p.(LimitedSignal).MaxX())
+	}
+	if _, ok := p.(PeriodicSignal); ok {
+		t.Error("periodic")
+	}
+	p, profile := NewProfile(Repeated{Stacked{Sine{Ms(2)}, Constant{unitY}, otherSignal{}}, 2})
+	if p.(PeriodicSignal).Period() != 0 {
+		t.Error(p.(PeriodicSignal).Period())
+	}
+	if nodes := profile.Nodes(); nodes[4].Type != "signals.otherSignal" {
+		t.Error(nodes)
+	}
+	p, _ = NewProfile(Stacked{Sine{Ms(2)}, Constant{unitY}})
+	if p.(PeriodicSignal).Period() != Ms(2) {
+		t.Error(p.(PeriodicSignal).Period())
+	}
+}
@@ -0,0 +1,153 @@
+package signals
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Recorder is an io.Writer that appends the PCM data written to it to PCM Signals, one per channel, that can be used while still being written to.
+// data starting with a Riff wave header has its format taken from it, (everything after the header is taken to be sample data, so a stream with placeholder sizes can be recorded) otherwise it's raw interleaved PCM data, (little-endian, unsigned for 1 byte, otherwise signed) in the format the Recorder was made with.
+// a Recorder is itself a PeriodicLimitedSignal, of its first channel.
+type Recorder struct {
+	// if not zero, only this length of the most recent data is kept. (set before writing.)
+	Keep         x
+	mutex        sync.RWMutex
+	started      bool
+	pending      []byte // data before the format is known, or of an incomplete frame.
+	sampleRate   uint32
+	samplePeriod x
+	sampleBytes  uint8
+	data         [][]byte                // by channel
+	signals      []PeriodicLimitedSignal // of data, made when it's written.
+	dropped      int64                   // samples
+}
+
+// NewRecorder returns a Recorder for raw PCM data of the given format, (unless the data written has a Riff wave header.)
+func NewRecorder(sampleRate uint32, sampleBytes uint8, channels uint16) *Recorder {
+	return &Recorder{sampleRate: sampleRate, sampleBytes: sampleBytes, data: make([][]byte, channels)}
+}
+
+// Write appends PCM data, or a Riff wave header.
+func (r *Recorder) Write(b []byte) (n int, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending = append(r.pending, b...)
+	if !r.started {
+		if len(r.pending) < 4 && bytes.HasPrefix([]byte("RIFF"), r.pending) {
+			return len(b), nil
+		}
+		if bytes.HasPrefix(r.pending, []byte("RIFF")) {
+			if len(r.pending) < 12 {
+				return len(b), nil
+			}
+			header := bytes.NewReader(r.pending)
+			_, format, err := readWaveHeader(header)
+			if err != nil {
+				if pe, ok := err.(errParsing); ok && (pe.error == io.EOF || pe.error == io.ErrUnexpectedEOF) {
+					return len(b), nil
+				}
+				return 0, err
+			}
+			r.sampleRate, r.sampleBytes, r.data = format.SampleRate, uint8(format.Bits/8), make([][]byte, format.Channels)
+			r.pending = r.pending[len(r.pending)-header.Len():]
+		}
+		switch r.sampleBytes {
+		case 1, 2, 3, 4, 6, 8:
+		default:
+			return 0, errors.New(fmt.Sprintf("Unsupported sample bytes (%d).", r.sampleBytes))
+		}
+		if len(r.data) == 0 {
+			return 0, errors.New("no channels.")
+		}
+		if r.sampleRate == 0 {
+			return 0, errors.New("Unsupported sample rate (0).")
+		}
+		r.samplePeriod = X(1 / float32(r.sampleRate))
+		r.started = true
+	}
+	sb := int(r.sampleBytes)
+	frame := sb * len(r.data)
+	whole := len(r.pending) / frame * frame
+	if len(r.data) == 1 {
+		r.data[0] = append(r.data[0], r.pending[:whole]...)
+	} else {
+		for i := 0; i < whole; i += frame {
+			for c := range r.data {
+				r.data[c] = append(r.data[c], r.pending[i+c*sb:i+(c+1)*sb]...)
+			}
+		}
+	}
+	r.pending = append(r.pending[:0], r.pending[whole:]...)
+	if r.Keep > 0 {
+		if excess := len(r.data[0])/sb - sampleIndex(r.Keep, r.samplePeriod) - 1; excess > 0 {
+			for c := range r.data {
+				r.data[c] = r.data[c][excess*sb:]
+			}
+			r.dropped += int64(excess)
+		}
+	}
+	r.signals = make([]PeriodicLimitedSignal, len(r.data))
+	for c, d := range r.data {
+		r.signals[c] = pcmSignal(r.sampleBytes, PCM{r.samplePeriod, d[:len(d):len(d)]})
+	}
+	return len(b), nil
+}
+
+// Signals returns PCM Signals of the data recorded so far, one per channel, with the same precision as the data. (nil if the format isn't known yet.)
+// with Keep set, x = 0 is the start of the data kept, see Dropped.
+func (r *Recorder) Signals() []PeriodicLimitedSignal {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if !r.started {
+		return nil
+	}
+	return append([]PeriodicLimitedSignal(nil), r.signals...)
+}
+
+// Dropped returns the length of data not kept, because of Keep.
+func (r *Recorder) Dropped() x {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return x(r.dropped) * r.samplePeriod
+}
+
+func (r *Recorder) channel0() PeriodicLimitedSignal {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if len(r.signals) == 0 {
+		return PCM16bit{}
+	}
+	return r.signals[0]
+}
+
+func (r *Recorder) property(p x) y {
+	return r.channel0().property(p)
+}
+
+func (r *Recorder) MaxX() x {
+	return r.channel0().MaxX()
+}
+
+func (r *Recorder) Period() x {
+	return r.channel0().Period()
+}
+
+// PCM Signal of a precision.
+func pcmSignal(sampleBytes uint8, p PCM) PeriodicLimitedSignal {
+	switch sampleBytes {
+	case 1:
+		return PCM8bit{p}
+	case 3:
+		return PCM24bit{p}
+	case 4:
+		return PCM32bit{p}
+	case 6:
+		return PCM48bit{p}
+	case 8:
+		return PCM64bit{p}
+	}
+	return PCM16bit{p}
+}
@@ -0,0 +1,100 @@
+package signals
+
+import (
+	"bytes"
+	"testing"
+)
+
+// write in awkward sized pieces.
+func writeInPieces(r *Recorder, data []byte, size int) error {
+	for len(data) > 0 {
+		if size > len(data) {
+			size = len(data)
+		}
+		if _, err := r.Write(data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+func TestRecorderWave(t *testing.T) {
+	s1, s2 := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}, Modulated{Sine{unitX / 300}, Pulse{unitX / 10}}
+	var b bytes.Buffer
+	Encode(&b, 3, 8000, s1.MaxX(), s1, s2)
+	decoded, err := Decode(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRecorder(44100, 2, 1)
+	if err := writeInPieces(r, b.Bytes(), 7); err != nil {
+		t.Fatal(err)
+	}
+	recorded := r.Signals()
+	if len(recorded) != 2 {
+		t.Fatal(len(recorded))
+	}
+	for c := range recorded {
+		if recorded[c].Period() != decoded[c].Period() || recorded[c].MaxX() != decoded[c].MaxX() {
+			t.Error(recorded[c].Period(), decoded[c].Period(), recorded[c].MaxX(), decoded[c].MaxX())
+		}
+		if e := MaxError(recorded[c], decoded[c], 0, s1.MaxX(), unitX/8000); e != 0 {
+			t.Error(c, e)
+		}
+	}
+	if _, ok := recorded[0].(PCM24bit); !ok {
+		t.Errorf("%T", recorded[0])
+	}
+}
+
+func TestRecorderRaw(t *testing.T) {
+	s := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}
+	var b bytes.Buffer
+	Encode(&b, 2, 8000, s.MaxX(), s)
+	r := NewRecorder(8000, 2, 1)
+	raw := b.Bytes()[44:]
+	writeInPieces(r, raw[:len(raw)/2+1], 5)
+	if r.MaxX() >= s.MaxX()/2+unitX/8000 {
+		t.Error("recorded too much.", r.MaxX())
+	}
+	writeInPieces(r, raw[len(raw)/2+1:], 5)
+	if e := MaxError(r, NewPCMSignal(s, s.MaxX(), 8000, 2), 0, s.MaxX(), unitX/8000); e != 0 {
+		t.Error(e)
+	}
+	if _, err := NewRecorder(8000, 5, 1).Write(raw); err == nil {
+		t.Error("5 byte samples recorded.")
+	}
+	if _, err := NewRecorder(0, 2, 1).Write(raw); err == nil {
+		t.Error("recorded at a rate of zero.")
+	}
+	// a header with a rate of zero.
+	Encode(&b, 2, 8000, s.MaxX(), s)
+	header := append([]byte(nil), b.Bytes()[:44]...)
+	header[24], header[25], header[26], header[27] = 0, 0, 0, 0
+	if _, err := NewRecorder(8000, 2, 1).Write(header); err == nil {
+		t.Error("recorded at a rate of zero.")
+	}
+	// periods the same as the PCM Signals Encode samples.
+	p := NewRecorder(44100, 2, 1)
+	p.Write(raw[:2])
+	if p.Period() != NewPCM(44100, nil).samplePeriod {
+		t.Error(p.Period(), NewPCM(44100, nil).samplePeriod)
+	}
+}
+
+func TestRecorderKeep(t *testing.T) {
+	r := NewRecorder(1000, 1, 1)
+	r.Keep = unitX / 10
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	writeInPieces(r, data, 33)
+	if r.MaxX() != unitX/10 || r.Dropped() != unitX*899/1000 {
+		t.Error(r.MaxX(), r.Dropped())
+	}
+	if pcm := r.Signals()[0].(PCM8bit); pcm.Data[0] != byte(899%256) || len(pcm.Data) != 101 {
+		t.Error(pcm.Data[0], len(pcm.Data))
+	}
+}
@@ -0,0 +1,104 @@
+package signals
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+func init() {
+	gob.Register(Interpolated{})
+}
+
+// Render samples Signals, directly into in-memory PCM Signals, one per Signal, with the same samples as Encode would write.
+// rendering an expensive Signal, that is used repeatedly, fixes its values at the cost of memory.
+// a Signal that panics with an error while getting its values, as a Wave does when it can't read its source, has the error returned, along with the samples so far, other panics, including runtime errors, aren't recovered.
+func Render(length x, sampleRate uint32, sampleBytes uint8, ss ...Signal) ([]PeriodicLimitedSignal, error) {
+	switch sampleBytes {
+	case 1, 2, 3, 4, 6, 8:
+	default:
+		return nil, errors.New(fmt.Sprintf("Unsupported sample bytes (%d).", sampleBytes))
+	}
+	if sampleRate == 0 {
+		return nil, errors.New("zero sample rate.")
+	}
+	if length < 0 {
+		return nil, errors.New("negative length.")
+	}
+	samplePeriod := X(1 / float32(sampleRate))
+	samples := sampleIndex(length, samplePeriod) + 1
+	rendered := make([]PeriodicLimitedSignal, len(ss))
+	for c, s := range ss {
+		data, err := render(s, samplePeriod, samples, int(sampleBytes))
+		rendered[c] = pcmSignal(sampleBytes, PCM{samplePeriod, data})
+		if err != nil {
+			return rendered[:c+1], err
+		}
+	}
+	return rendered, nil
+}
+
+// Freeze returns a Signal rendered, as Render, optionally Interpolated.
+func Freeze(s Signal, length x, sampleRate uint32, sampleBytes uint8, interpolate bool) (PeriodicLimitedSignal, error) {
+	rendered, err := Render(length, sampleRate, sampleBytes, s)
+	if len(rendered) == 0 {
+		return nil, err
+	}
+	if interpolate {
+		return Interpolated{rendered[0]}, err
+	}
+	return rendered[0], err
+}
+
+// sample a Signal into PCM data.
+func render(s Signal, samplePeriod x, samples, sampleBytes int) (data []byte, err error) {
+	data = make([]byte, samples*sampleBytes)
+	i := 0
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(runtime.Error); ok {
+				panic(e)
+			}
+			var isError bool
+			if err, isError = e.(error); !isError {
+				panic(e)
+			}
+			data = data[:i*sampleBytes]
+		}
+	}()
+	for ; i < samples; i++ {
+		encodeSample(data[i*sampleBytes:(i+1)*sampleBytes], s.property(x(i)*samplePeriod))
+	}
+	return
+}
+
+// Interpolated is a PeriodicSignal, with property values interpolated linearly between those at multiples of its Period, so stepped PCM Signals become continuous.
+type Interpolated struct {
+	PeriodicSignal
+}
+
+func (s Interpolated) property(p x) y {
+	period := s.Period()
+	if period <= 0 {
+		return s.PeriodicSignal.property(p)
+	}
+	temp := modX(p, period)
+	if temp < 0 {
+		temp += period
+	}
+	l1 := x(s.PeriodicSignal.property(p - temp))
+	if temp == 0 {
+		return y(l1)
+	}
+	l2 := x(s.PeriodicSignal.property(p-temp+period))/period - l1/period
+	return y(l1 + l2*temp)
+}
+
+// MaxX is the embedded PeriodicSignal's, if it's also a LimitedSignal, otherwise zero.
+func (s Interpolated) MaxX() x {
+	if ls, ok := s.PeriodicSignal.(LimitedSignal); ok {
+		return ls.MaxX()
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package signals
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRenderAsEncode(t *testing.T) {
+	s1, s2 := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}, Modulated{Sine{unitX / 300}, Pulse{unitX / 20}}
+	for _, sb := range []uint8{1, 2, 3, 4, 6, 8} {
+		var b bytes.Buffer
+		Encode(&b, sb, 8000, s1.MaxX(), s1, s2)
+		decoded, err := Decode(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rendered, err := Render(s1.MaxX(), 8000, sb, s1, s2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rendered) != 2 {
+			t.Fatal(len(rendered))
+		}
+		for c := range rendered {
+			if rendered[c].Period() != decoded[c].Period() || rendered[c].MaxX() != decoded[c].MaxX() {
+				t.Error(sb, c, rendered[c].Period(), decoded[c].Period(), rendered[c].MaxX(), decoded[c].MaxX())
+			}
+			if e := MaxError(rendered[c], decoded[c], 0, s1.MaxX(), unitX/8000); e != 0 {
+				t.Error(sb, c, e)
+			}
+		}
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	if _, err := Render(unitX, 8000, 5, Sine{unitX}); err == nil {
+		t.Error("5 byte samples rendered.")
+	}
+	if _, err := Render(unitX, 0, 2, Sine{unitX}); err == nil {
+		t.Error("zero rate rendered.")
+	}
+	// a Wave panics reading past its end.
+	rendered, err := Render(unitX*10, 8000, 2, Sine{unitX}, &Wave{URL: testDataURL})
+	if err == nil || len(rendered) != 2 {
+		t.Fatal(err, len(rendered))
+	}
+	if rendered[0].MaxX() < unitX*10 || rendered[1].MaxX() >= unitX*10 {
+		t.Error(rendered[0].MaxX(), rendered[1].MaxX())
+	}
+}
+
+// a Signal that panics, with a value, after its first x.
+type panickingSignal struct{ value func() interface{} }
+
+func (s panickingSignal) property(p x) y {
+	if p > 0 {
+		panic(s.value())
+	}
+	return 0
+}
+
+// only panics with errors that aren't runtime errors are returned.
+func TestRenderPanics(t *testing.T) {
+	// the sample at zero.
+	one, _ := Render(0, 8000, 2, Constant{})
+	rendered, err := Render(unitX, 8000, 2, panickingSignal{func() interface{} { return errors.New("failed") }})
+	if err == nil || len(rendered) != 1 || rendered[0].MaxX() != one[0].MaxX() {
+		t.Error(err, rendered)
+	}
+	for _, v := range []func() interface{}{
+		func() interface{} { return "failed" },
+		func() interface{} {
+			var s []y
+			return s[1]
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("not panicked.")
+				}
+			}()
+			Render(unitX, 8000, 2, panickingSignal{v})
+		}()
+	}
+}
+
+func TestFreezeInterpolated(t *testing.T) {
+	s := RampUp{unitX}
+	f, err := Freeze(s, unitX, 100, 8, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.(Interpolated); !ok {
+		t.Fatalf("%T", f)
+	}
+	// a ramp, so interpolating makes it almost exact, stepped is out by up to a sample.
+	if e := MaxError(s, f, 0, unitX*99/100, unitX/1000); e > unitY/1e6 {
+		t.Error(e)
+	}
+	stepped, _ := Freeze(s, unitX, 100, 8, false)
+	if e := MaxError(s, stepped, 0, unitX*99/100, unitX/1000); e < unitY/200 {
+		t.Error(e)
+	}
+}
@@ -0,0 +1,132 @@
+package signals
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SampleFormat describes raw PCM sample data.
+// Bytes per sample can be 1 to 8. (Riff wave PCM data is little-endian, unsigned for 1 byte, otherwise signed.)
+type SampleFormat struct {
+	Rate      uint32
+	Bytes     uint8
+	Unsigned  bool
+	BigEndian bool
+}
+
+// SampleReader is an io.Reader of raw PCM data, sampled from Signals, interleaved if more than one, as they are read.
+// if all the Signals are LimitedSignals, it ends after their largest MaxX, and can Seek from the end, otherwise it doesn't end.
+type SampleReader struct {
+	SampleFormat
+	Signals      []Signal
+	samplePeriod x
+	offset       int64 // bytes
+	end          int64 // bytes, -1 for no end.
+	frame        []byte
+	sampled      int64 // index of the samples in frame, -1 for none.
+}
+
+// NewSampleReader returns a SampleReader of Signals, in a SampleFormat.
+func NewSampleReader(f SampleFormat, ss ...Signal) *SampleReader {
+	r := SampleReader{SampleFormat: f, Signals: ss, samplePeriod: X(1 / float32(f.Rate)), end: -1, frame: make([]byte, int(f.Bytes)*len(ss)), sampled: -1}
+	if end, limited := maxX(ss); limited && f.Rate > 0 {
+		r.end = (int64(sampleIndex(end, r.samplePeriod)) + 1) * int64(len(r.frame))
+	}
+	return &r
+}
+
+// Read reads the next raw PCM data.
+func (r *SampleReader) Read(p []byte) (n int, err error) {
+	if r.Bytes < 1 || r.Bytes > 8 {
+		return 0, errors.New(fmt.Sprintf("Unsupported sample bytes (%d).", r.Bytes))
+	}
+	if r.Rate == 0 {
+		return 0, errors.New("Unsupported sample rate (0).")
+	}
+	if len(r.frame) == 0 {
+		return 0, io.EOF
+	}
+	for n < len(p) {
+		if r.end >= 0 && r.offset >= r.end {
+			if n == 0 {
+				err = io.EOF
+			}
+			return
+		}
+		if i := r.offset / int64(len(r.frame)); i != r.sampled {
+			r.sampleFrame(i)
+			r.sampled = i
+		}
+		c := copy(p[n:], r.frame[r.offset%int64(len(r.frame)):])
+		n += c
+		r.offset += int64(c)
+	}
+	return
+}
+
+// WriteTo writes raw PCM data, until the end, if there is one, or an error.
+func (r *SampleReader) WriteTo(w io.Writer) (n int64, err error) {
+	buf := make([]byte, streamBlockSamples*len(r.frame))
+	for {
+		c, rerr := r.Read(buf)
+		if c > 0 {
+			c, err = w.Write(buf[:c])
+			n += int64(c)
+			if err != nil {
+				return
+			}
+		}
+		if rerr == io.EOF {
+			return
+		}
+		if rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// Seek sets the offset, in bytes, for the next Read. seeking from the end is an error if there is no end.
+func (r *SampleReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		if r.end < 0 {
+			return r.offset, errors.New("SampleReader has no end to Seek from.")
+		}
+		offset += r.end
+	default:
+		return r.offset, errors.New("invalid whence.")
+	}
+	if offset < 0 {
+		return r.offset, errors.New("negative position.")
+	}
+	r.offset, r.sampled = offset, -1
+	return offset, nil
+}
+
+// sample all the Signals, into r.frame.
+func (r *SampleReader) sampleFrame(i int64) {
+	p := x(i) * r.samplePeriod
+	for c, s := range r.Signals {
+		r.SampleFormat.encode(r.frame[c*int(r.Bytes):(c+1)*int(r.Bytes)], s.property(p))
+	}
+}
+
+// encode a value into a sample, of len(b) bytes, in this format.
+func (f SampleFormat) encode(b []byte, v y) {
+	n := len(b)
+	for i := range b {
+		b[i] = byte(fullScale(v) >> uint(yBits-8*(n-i)))
+	}
+	if f.Unsigned {
+		b[n-1] ^= 0x80
+	}
+	if f.BigEndian {
+		for i := 0; i < n/2; i++ {
+			b[i], b[n-1-i] = b[n-1-i], b[i]
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package signals
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestSampleReaderAsEncode(t *testing.T) {
+	s1, s2 := Modulated{Sine{unitX / 400}, Pulse{unitX / 10}}, Modulated{Sine{unitX / 300}, Pulse{unitX / 20}}
+	for _, f := range []SampleFormat{{8000, 1, true, false}, {8000, 2, false, false}, {44100, 3, false, false}, {8000, 8, false, false}} {
+		var encoded bytes.Buffer
+		Encode(&encoded, f.Bytes, f.Rate, s1.MaxX(), s1, s2)
+		data, err := ioutil.ReadAll(NewSampleReader(f, s1, s2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, encoded.Bytes()[44:]) {
+			t.Errorf("%+v %d bytes, encoded %d bytes", f, len(data), encoded.Len()-44)
+		}
+	}
+}
+
+func TestSampleReaderFormat(t *testing.T) {
+	read := func(f SampleFormat) []byte {
+		b := make([]byte, f.Bytes)
+		io.ReadFull(NewSampleReader(f, Constant{fromFullScale(0x3fff000000000000)}), b)
+		return b
+	}
+	if b := read(SampleFormat{8000, 2, false, false}); !bytes.Equal(b, []byte{0xff, 0x3f}) {
+		t.Errorf("% x", b)
+	}
+	if b := read(SampleFormat{8000, 2, false, true}); !bytes.Equal(b, []byte{0x3f, 0xff}) {
+		t.Errorf("% x", b)
+	}
+	if b := read(SampleFormat{8000, 2, true, false}); !bytes.Equal(b, []byte{0xff, 0xbf}) {
+		t.Errorf("% x", b)
+	}
+	if b := read(SampleFormat{8000, 3, true, true}); !bytes.Equal(b, []byte{0xbf, 0xff, 0x00}) {
+		t.Errorf("% x", b)
+	}
+	if _, err := NewSampleReader(SampleFormat{8000, 9, false, false}, Sine{unitX}).Read(make([]byte, 10)); err == nil {
+		t.Error("9 bytes per sample read.")
+	}
+	if _, err := NewSampleReader(SampleFormat{0, 2, false, false}, Pulse{unitX}).Read(make([]byte, 10)); err == nil {
+		t.Error("zero sample rate read.")
+	}
+}
+
+// read a byte at a time, each frame is only sampled once.
+func TestSampleReaderSamplesOnce(t *testing.T) {
+	var n int
+	r := NewSampleReader(SampleFormat{8000, 3, false, false}, countedSignal{Sine{unitX / 400}, &n}, Pulse{unitX / 100})
+	all, err := ioutil.ReadAll(io.LimitReader(r, 3*2*50))
+	if err != nil || len(all) != 3*2*50 {
+		t.Fatal(err, len(all))
+	}
+	b := make([]byte, 1)
+	for i := 0; i < 3*2*50; i++ {
+		r.Read(b)
+	}
+	if n != 100 {
+		t.Error(n)
+	}
+}
+
+func TestSampleReaderSeek(t *testing.T) {
+	r := NewSampleReader(SampleFormat{8000, 2, false, false}, Pulse{unitX / 10}, Modulated{Sine{unitX / 400}, Pulse{unitX / 20}})
+	all, _ := ioutil.ReadAll(r)
+	if p, err := r.Seek(-7, io.SeekEnd); err != nil || p != int64(len(all)-7) {
+		t.Fatal(p, err)
+	}
+	tail, _ := ioutil.ReadAll(r)
+	if !bytes.Equal(tail, all[len(all)-7:]) {
+		t.Error("tail differs.")
+	}
+	r.Seek(3, io.SeekStart)
+	var b bytes.Buffer
+	if n, err := r.WriteTo(&b); err != nil || n != int64(len(all)-3) || !bytes.Equal(b.Bytes(), all[3:]) {
+		t.Error(n, err)
+	}
+	if _, err := NewSampleReader(SampleFormat{8000, 2, false, false}, Sine{unitX / 400}).Seek(0, io.SeekEnd); err == nil {
+		t.Error("unlimited Seek from end.")
+	}
+}
@@ -1,3 +1,6 @@
+//go:build !float64
+// +build !float64
+
 package signals
 
 import (
@@ -32,7 +32,7 @@ type Constant struct {
 }
 
 func NewConstant(DB float64) Constant {
-	return Constant{y(unitYfloat64 * Vol(DB))}
+	return Constant{DBFS(DB)}
 }
 
 func (s Constant) property(p x) y {
@@ -96,7 +96,7 @@ type Square struct {
 }
 
 func (s Square) property(p x) y {
-	if p%s.Cycle >= s.Cycle/2 {
+	if modX(p, s.Cycle) >= s.Cycle/2 {
 		return -unitY
 	} else {
 		return unitY
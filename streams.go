@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 func init() {
@@ -30,16 +31,36 @@ type Wave struct {
 	Offset
 	URL    string
 	reader io.Reader
+	mutex  sync.Mutex
+}
+
+// whether the URL has been read, so, for example, MaxX is known.
+func (s *Wave) loaded() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.reader != nil
+}
+
+// zero until the URL has been read, (by the first property call.)
+func (s *Wave) MaxX() x {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.reader == nil {
+		return 0
+	}
+	return s.Offset.MaxX()
 }
 
 func (s *Wave) property(p x) y {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.reader == nil {
 		wav, err := NewWave(s.URL)
 		failOn(err)
 		s.Offset = wav.Offset
 		s.reader = wav.reader
 	}
-	for p > s.MaxX() {
+	for p > s.Offset.MaxX() {
 		// append available data onto the PCM slice.
 		// also possibly shift off some data, shortening the PCM slice, retaining at least two buffer lengths.
 		// partial samples are read but not accessed by property.
@@ -146,17 +167,17 @@ func NewWave(URL string) (*Wave, error) {
 	b = b[:n]
 	switch bytes {
 	case 1:
-		return &Wave{Offset{NewPCM8bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM8bit(rate, b), 0}, URL: URL, reader: r}, nil
 	case 2:
-		return &Wave{Offset{NewPCM16bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM16bit(rate, b), 0}, URL: URL, reader: r}, nil
 	case 3:
-		return &Wave{Offset{NewPCM24bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM24bit(rate, b), 0}, URL: URL, reader: r}, nil
 	case 4:
-		return &Wave{Offset{NewPCM32bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM32bit(rate, b), 0}, URL: URL, reader: r}, nil
 	case 6:
-		return &Wave{Offset{NewPCM48bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM48bit(rate, b), 0}, URL: URL, reader: r}, nil
 	case 8:
-		return &Wave{Offset{NewPCM64bit(rate, b), 0}, URL, r}, nil
+		return &Wave{Offset: Offset{NewPCM64bit(rate, b), 0}, URL: URL, reader: r}, nil
 	}
 	return nil, errors.New("Sample Bytes not supported:"+URL)
 }
@@ -0,0 +1,396 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+Text form of a Signal.
+
+A Signal is written as its type name followed by its parameters in brackets, in the same order as its Go fields, or its New... function's parameters, Signals being written the same way, so nested. For example:
+
+	Modulated(Sine(400Hz), Constant(-6dB))
+
+x values are seconds, but can have units; "s", "ms", "us", "ns" or, for frequency, "Hz" or "kHz", which are converted to the period. (so 400Hz is the same as 0.0025, 2.5ms and 1/400.)
+
+y values are fractions of the maximum, but can have units; "%" or "dB". (so -6dB is the same as NewConstant(-6).)
+
+numbers can be decimal or a ratio, (except whole numbers, like a Cached's Size), like 1/400, bools are true or false, URLs and base64 data are Go quoted strings, bit patterns are binary with a 0b prefix.
+
+"//" starts a comment, to the end of the line.
+*/
+
+// ParseSignal makes a Signal from its text form.
+func ParseSignal(text string) (Signal, error) {
+	p := parser{text: text}
+	s, err := p.signal()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.text != "" {
+		return nil, p.errorAt(t, "unexpected text after Signal.")
+	}
+	return s, nil
+}
+
+// FormatSignal returns the text form of a Signal, which ParseSignal can use to make it again.
+// errors if the Signal, or any Signal it contains, isn't one of this package's types.
+func FormatSignal(s Signal) (string, error) {
+	var b bytes.Buffer
+	if err := formatSignal(&b, s); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func formatSignal(b *bytes.Buffer, s Signal) error {
+	name, values, err := describe(s)
+	if err != nil {
+		return err
+	}
+	st := signalTypes[name]
+	b.WriteString(name)
+	b.WriteByte('(')
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if k := st.parameter(i).kind; k.isSignal() {
+			if err := formatSignal(b, v.(Signal)); err != nil {
+				return err
+			}
+		} else {
+			b.WriteString(formatValue(v, k))
+		}
+	}
+	b.WriteByte(')')
+	return nil
+}
+
+func (k kind) isSignal() bool {
+	return k == signalKind || k == limitedSignalKind || k == periodicSignalKind
+}
+
+// text form of a parameter's value.
+func formatValue(v interface{}, k kind) string {
+	switch vt := v.(type) {
+	case x:
+		if k == periodKind {
+			return formatPeriod(vt)
+		}
+		return formatX(vt)
+	case y:
+		return formatY(vt)
+	case int:
+		return strconv.Itoa(vt)
+	case Curve:
+		return vt.String()
+	case float32:
+		return strconv.FormatFloat(float64(vt), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(vt, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(vt)
+	case string:
+		return strconv.Quote(vt)
+	case []byte:
+		return strconv.Quote(base64.StdEncoding.EncodeToString(vt))
+	case *big.Int:
+		return "0b" + vt.Text(2)
+	}
+	return fmt.Sprint(v)
+}
+
+// exact decimal seconds.
+func formatX(p x) string {
+	return decimal(ratX(p))
+}
+
+// as a frequency, if one is exact, otherwise seconds.
+// there can be a range of frequencies that give the same period, the one with the most trailing zeros, then the lowest, is used.
+func formatPeriod(p x) string {
+	hz := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) + "Hz" }
+	if f := float64(unitX) / float64(p); p > 0 && (floatXY || p > 1) {
+		for m := 1e9; m >= 1; m /= 10 {
+			// down from the multiple just above, while in the range.
+			found := ""
+			for c := math.Ceil(f/m) * m; c > 0; c -= m {
+				if s := hz(c); parsesTo(s, xKind, p) {
+					found = s
+				} else if c < f {
+					break
+				}
+			}
+			if found != "" {
+				return found
+			}
+		}
+	}
+	return formatX(p)
+}
+
+// shortest of dB or % that is exact, dB only if short.
+func formatY(v y) string {
+	if v == 0 {
+		return "0"
+	}
+	for places := 0; places < 20; places++ {
+		if v > 0 && places < 6 {
+			if s := strconv.FormatFloat(DB(float64(v)/unitYfloat64), 'f', places, 64) + "dB"; parsesTo(s, yKind, v) {
+				return s
+			}
+		}
+		if s := strconv.FormatFloat(100*float64(v)/float64(unitY), 'f', places, 64) + "%"; parsesTo(s, yKind, v) {
+			return s
+		}
+	}
+	// beyond float64 precision
+	r := ratY(v)
+	r.Mul(r, big.NewRat(100, 1))
+	for places := 17; ; places++ {
+		if s := strings.TrimRight(r.FloatString(places), "0") + "%"; parsesTo(s, yKind, v) {
+			return s
+		}
+	}
+}
+
+func parsesTo(s string, k kind, v interface{}) bool {
+	pv, err := parseValue(s, k)
+	return err == nil && pv == v
+}
+
+// value of a parameter, of a particular kind, from its text form.
+func parseValue(s string, k kind) (interface{}, error) {
+	switch k {
+	case xKind, periodKind:
+		n, unit := splitUnit(s, "kHz", "Hz", "ms", "us", "µs", "ns", "s")
+		r, ok := new(big.Rat).SetString(n)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%q is not a number.", n))
+		}
+		switch unit {
+		case "kHz", "Hz":
+			if r.Sign() == 0 {
+				return nil, errors.New("zero frequency.")
+			}
+			if unit == "kHz" {
+				r.Mul(r, big.NewRat(1000, 1))
+			}
+			r.Inv(r)
+		case "ms":
+			r.Mul(r, big.NewRat(1, 1e3))
+		case "us", "µs":
+			r.Mul(r, big.NewRat(1, 1e6))
+		case "ns":
+			r.Mul(r, big.NewRat(1, 1e9))
+		}
+		return xRat(r)
+	case yKind:
+		n, unit := splitUnit(s, "dB", "%")
+		r, ok := new(big.Rat).SetString(n)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%q is not a number.", n))
+		}
+		switch unit {
+		case "dB":
+			db, _ := r.Float64()
+			return NewConstant(db).Constant, nil
+		case "%":
+			r.Mul(r, big.NewRat(1, 100))
+		}
+		if r.Cmp(big.NewRat(1, 1)) > 0 || r.Cmp(big.NewRat(-1, 1)) < 0 {
+			return nil, errors.New(fmt.Sprintf("%q is out of range.", s))
+		}
+		return yRat(r)
+	case intKind:
+		return strconv.Atoi(s)
+	case curveKind:
+		for c, name := range curveNames {
+			if s == name {
+				return Curve(c), nil
+			}
+		}
+	case float32Kind:
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	case float64Kind:
+		return strconv.ParseFloat(s, 64)
+	case boolKind:
+		return strconv.ParseBool(s)
+	case stringKind:
+		return strconv.Unquote(s)
+	case bytesKind:
+		q, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.DecodeString(q)
+	case bitsKind:
+		i, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%q is not a bit pattern.", s))
+		}
+		return i, nil
+	}
+	return nil, errors.New(fmt.Sprintf("%q is not a %s.", s, k))
+}
+
+// split off the first matching unit suffix.
+func splitUnit(s string, units ...string) (number, unit string) {
+	for _, u := range units {
+		if strings.HasSuffix(s, u) {
+			return s[:len(s)-len(u)], u
+		}
+	}
+	return s, ""
+}
+
+// nearest int64, halves towards zero, so, for example, a half of unitY is the same as unitY/2.
+func rounded(r *big.Rat) (int64, error) {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Abs(m).Lsh(m, 1).Cmp(r.Denom()) > 0 {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, errors.New(r.FloatString(3) + " is out of range.")
+	}
+	return q.Int64(), nil
+}
+
+// recursive descent parser of the text form.
+type parser struct {
+	text string
+	pos  int
+}
+
+type token struct {
+	text   string
+	offset int
+}
+
+// next token, a bracket, a comma, a quoted string, or a word, which is anything else not including whitespace. empty at the end.
+func (p *parser) next() (t token) {
+	for p.pos < len(p.text) {
+		if strings.HasPrefix(p.text[p.pos:], "//") {
+			if e := strings.IndexByte(p.text[p.pos:], '\n'); e >= 0 {
+				p.pos += e
+			} else {
+				p.pos = len(p.text)
+			}
+			continue
+		}
+		if c := p.text[p.pos]; c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			break
+		}
+		p.pos++
+	}
+	t.offset = p.pos
+	if p.pos == len(p.text) {
+		return
+	}
+	switch p.text[p.pos] {
+	case '(', ')', ',':
+		p.pos++
+	case '"':
+		for p.pos++; p.pos < len(p.text) && p.text[p.pos] != '"'; p.pos++ {
+			if p.text[p.pos] == '\\' {
+				p.pos++
+			}
+		}
+		p.pos++
+		if p.pos > len(p.text) {
+			p.pos = len(p.text)
+		}
+	default:
+		for ; p.pos < len(p.text) && !strings.ContainsRune(" \t\n\r(),\"", rune(p.text[p.pos])); p.pos++ {
+			if strings.HasPrefix(p.text[p.pos:], "//") {
+				break
+			}
+		}
+	}
+	t.text = p.text[t.offset:p.pos]
+	return
+}
+
+func (p *parser) peek() token {
+	pos := p.pos
+	t := p.next()
+	p.pos = pos
+	return t
+}
+
+func (p *parser) errorAt(t token, message string) error {
+	line := strings.Count(p.text[:t.offset], "\n") + 1
+	column := t.offset - strings.LastIndex(p.text[:t.offset], "\n")
+	return errors.New(fmt.Sprintf("line %d column %d: %s", line, column, message))
+}
+
+// parse a Signal, its name, and bracketed parameters.
+func (p *parser) signal() (Signal, error) {
+	nameToken := p.next()
+	if nameToken.text == "" {
+		return nil, p.errorAt(nameToken, "missing Signal.")
+	}
+	if r := []rune(nameToken.text)[0]; !unicode.IsLetter(r) {
+		return nil, p.errorAt(nameToken, fmt.Sprintf("%q is not a Signal type name.", nameToken.text))
+	}
+	st, ok := signalTypes[nameToken.text]
+	if !ok {
+		return nil, p.errorAt(nameToken, fmt.Sprintf("unknown Signal type %q.", nameToken.text))
+	}
+	if t := p.next(); t.text != "(" {
+		return nil, p.errorAt(t, "expected '(' after "+nameToken.text+".")
+	}
+	var values []interface{}
+	if p.peek().text == ")" {
+		p.next()
+	} else {
+		for {
+			if !st.variadic && len(values) == len(st.parameters) {
+				return nil, p.errorAt(p.peek(), fmt.Sprintf("%s only has %d parameters.", nameToken.text, len(st.parameters)))
+			}
+			param := st.parameter(len(values))
+			if param.kind.isSignal() {
+				t := p.peek()
+				s, err := p.signal()
+				if err != nil {
+					return nil, err
+				}
+				if err := param.kind.check(s); err != nil {
+					return nil, p.errorAt(t, nameToken.text+" "+param.name+": "+err.Error())
+				}
+				values = append(values, s)
+			} else {
+				t := p.next()
+				v, err := parseValue(t.text, param.kind)
+				if err != nil {
+					return nil, p.errorAt(t, nameToken.text+" "+param.name+": "+err.Error())
+				}
+				values = append(values, v)
+			}
+			t := p.next()
+			if t.text == ")" {
+				break
+			}
+			if t.text != "," {
+				return nil, p.errorAt(t, "expected ',' or ')'.")
+			}
+		}
+	}
+	if len(values) < len(st.parameters) && !(st.variadic && len(values) == len(st.parameters)-1) {
+		return nil, p.errorAt(nameToken, fmt.Sprintf("%s needs %d parameters, has %d.", nameToken.text, len(st.parameters), len(values)))
+	}
+	return makeSignal(nameToken.text, values)
+}
@@ -0,0 +1,164 @@
+package signals
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// one of each of the built-in types.
+func builtins() []Signal {
+	pattern := new(big.Int)
+	pattern.SetString("10110111", 2)
+	pcm := []byte{0, 0, 0, 10, 0, 20, 0, 30, 0, 40, 0, 50, 0, 60, 0, 70, 0, 80, 0, 90, 0, 100}
+	return []Signal{
+		Constant{0},
+		NewConstant(-6),
+		Constant{-unitY / 3},
+		Sine{unitX / 400},
+		Sinc{unitX / 3},
+		Gauss{2e16},
+		Pulse{unitX * 375 / 1000},
+		Square{unitX / 100},
+		RampUp{unitX},
+		RampDown{unitX / 7},
+		Heavyside{},
+		Sigmoid{unitX / 10},
+		PulsePattern{*pattern, unitX / 10},
+		NewADSREnvelope(unitX, unitX/2, unitX, unitY/2, unitX/4),
+		NewNoise(),
+		Shifted{Sine{unitX / 400}, -unitX / 10},
+		Offset{Pulse{unitX}, unitX / 3},
+		Compressed{Sine{unitX}, 0.3},
+		Looped{Pulse{unitX / 4}, unitX / 2},
+		Repeated{Sine{unitX}, 2.5},
+		Inverted{Sine{unitX}},
+		Reversed{RampUp{unitX}},
+		Reflected{Sine{unitX}},
+		Interpolated{PCM16bit{NewPCM(8000, []byte{0, 0, 0, 0x40, 0, 0xc0})}},
+		RateModulated{Sine{unitX / 100}, Sine{unitX}, unitX / 1000},
+		NewSegmented(Sine{unitX}, unitX/64),
+		NewTriggered(Sine{unitX}, unitY/2, true, unitX/100, unitX),
+		NewCached(Sine{unitX}, 0, 0),
+		NewCached(Sine{unitX / 100}, 100, unitX/8000),
+		NewBuffered(Sine{unitX / 100}, unitX/8000, 256, 2),
+		FadeIn{Sine{unitX / 400}, unitX / 10, SCurve},
+		FadeOut{Pulse{unitX}, unitX / 4, EqualPower},
+		NewCrossfadeSequence(unitX/10, Logarithmic, Pulse{unitX}, Offset{Pulse{unitX}, unitX / 2}, Pulse{unitX / 2}),
+		Modulated{},
+		Modulated{Sine{unitX / 400}, NewConstant(-6)},
+		Composite{Sine{unitX / 400}, Sine{unitX / 450}},
+		Stacked{Sine{unitX / 350}, Sine{unitX / 450}},
+		Sequenced{Pulse{unitX}, Offset{Pulse{unitX}, unitX}},
+		NewIndexedSequence(Pulse{unitX}, Offset{Pulse{unitX}, unitX}),
+		NewIndexedSequence(),
+		NewEvent(unitX/2, Pulse{unitX}),
+		Event{Modulated{Sine{unitX / 400}, Pulse{unitX}}, unitX, unitY / 2, FadeIn{Constant{unitY}, unitX / 10, Linear}},
+		NewTimeline(NewEvent(unitX, Pulse{unitX}), Event{Pulse{unitX}, 0, unitY / 2, RampDown{unitX}}),
+		NewTimeline(),
+		NewPCM8bit(8000, pcm),
+		NewPCM16bit(44100, pcm),
+		PCM16bit{PCM{unitX / 44100, pcm}},
+		NewPCM24bit(8000, pcm[:21]),
+		NewPCM32bit(8000, pcm[:20]),
+		NewPCM48bit(8000, pcm[:18]),
+		NewPCM64bit(8000, pcm[:16]),
+		&Wave{URL: testDataURL},
+	}
+}
+
+func ExampleFormatSignal() {
+	s, _ := FormatSignal(Modulated{Looped{Pulse{unitX * 375 / 1000}, unitX * 75 / 100}, Sine{unitX / 400}, NewConstant(-6)})
+	fmt.Println(s)
+	// Output: Modulated(Looped(Pulse(0.375), 0.75), Sine(400Hz), Constant(-6dB))
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, s := range builtins() {
+		text, err := FormatSignal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s2, err := ParseSignal(text)
+		if err != nil {
+			t.Fatal(text, err)
+		}
+		text2, err := FormatSignal(s2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text != text2 {
+			t.Errorf("%s != %s", text, text2)
+		}
+		if _, ok := s.(*Wave); ok {
+			continue
+		}
+		if e := MaxError(s, s2, -unitX, unitX*2, unitX/1000); e != 0 {
+			t.Errorf("%s differs by %v", text, e)
+		}
+	}
+}
+
+func TestTextParse(t *testing.T) {
+	s, err := ParseSignal(`
+		// dial tone
+		Modulated(
+			Stacked(Sine(1/350), Sine(450 Hz)),
+			Constant(50%)
+		)`)
+	if err == nil {
+		t.Fatal("space before unit not an error.")
+	}
+	s, err = ParseSignal(`
+		// dial tone
+		Modulated(
+			Stacked(Sine(1/350), Sine(450Hz)), // two tones
+			Constant(50%)
+		)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := MaxError(s, Modulated{Stacked{Sine{X(1.0 / 350)}, Sine{X(1.0 / 450)}}, Constant{unitY / 2}}, 0, unitX, unitX/8000); e != 0 {
+		t.Error(e)
+	}
+	for _, text := range []string{"2.5ms", "2500us", "2500000ns", "0.0025", "0.0025s", "1/400", "400Hz", "0.4kHz"} {
+		s, err := ParseSignal("Sine(" + text + ")")
+		if err != nil {
+			t.Error(err)
+		} else if s.(Sine).Cycle != unitX/400 {
+			t.Error(text, s)
+		}
+	}
+}
+
+func TestTextParseErrors(t *testing.T) {
+	for text, message := range map[string]string{
+		"":                                "missing Signal",
+		"Sine":                            "expected '('",
+		"Sin(1)":                          "unknown Signal type",
+		"Sine()":                          "needs 1 parameters",
+		"Sine(1,2)":                       "only has 1 parameters",
+		"Sine(1s":                         "expected ',' or ')'",
+		"Sine(one)":                       "not a number",
+		"Constant(2)":                     "out of range",
+		"Offset(Sine(1), 1)":              "not a LimitedSignal",
+		"Sine(1) Sine(1)":                 "unexpected text",
+		"Modulated(Sine(1),\n Cosine(1))": "line 2 column 2",
+	} {
+		_, err := ParseSignal(text)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("%q: %v, expected %q", text, err, message)
+		}
+	}
+}
+
+type unknownSignal struct{}
+
+func (unknownSignal) property(x) y { return 0 }
+
+func TestTextFormatUnknown(t *testing.T) {
+	if _, err := FormatSignal(Inverted{unknownSignal{}}); err == nil {
+		t.Error("unknown type formatted.")
+	}
+}
@@ -0,0 +1,203 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sort"
+)
+
+func init() {
+	gob.Register(Event{})
+	gob.Register(Timeline{})
+}
+
+// Event is a LimitedSignal placed at Start, its values multiplied by Gain, and by Envelope if it has one, (with x's from Start.)
+// it's zero outside from Start to Start plus the LimitedSignal's MaxX.
+type Event struct {
+	LimitedSignal
+	Start    x
+	Gain     y      // unitY for unchanged.
+	Envelope Signal // nil for none.
+}
+
+// NewEvent returns an Event of a LimitedSignal at start, with unit Gain and no Envelope.
+func NewEvent(start x, s LimitedSignal) Event {
+	return Event{s, start, unitY, nil}
+}
+
+func (e Event) property(p x) y {
+	q := p - e.Start
+	if q < 0 || q >= e.LimitedSignal.MaxX() {
+		return 0
+	}
+	return e.value(q)
+}
+
+// value at q from the Event's Start.
+func (e Event) value(q x) y {
+	v := e.LimitedSignal.property(q)
+	if v == 0 {
+		return 0
+	}
+	if e.Gain != unitY {
+		v = multiplyY(v, e.Gain)
+	}
+	if e.Envelope != nil {
+		v = multiplyY(v, e.Envelope.property(q))
+	}
+	return v
+}
+
+// Start plus the LimitedSignal's MaxX.
+func (e Event) MaxX() x {
+	return e.Start + e.LimitedSignal.MaxX()
+}
+
+// Timeline is a LimitedSignal, the sum of Events, the same as a Composite of them, but it keeps them in an interval tree, so only those that overlap an x are evaluated.
+// Events' MaxX's are got when they're added, so shouldn't change.
+// Add and Remove change it, (and its copies), they shouldn't be used while values are being got.
+// use NewTimeline, a Timeline literal is empty, and can't be added to.
+type Timeline struct {
+	index *timelineIndex
+}
+
+// Events sorted by Start, as an implicit binary tree, the root of any range of them is in its middle, with the largest MaxX in each subtree at its root.
+type timelineIndex struct {
+	events  []Event
+	ends    []x
+	maxEnds []x
+}
+
+// NewTimeline returns a Timeline of Events.
+func NewTimeline(es ...Event) Timeline {
+	t := Timeline{&timelineIndex{}}
+	t.Add(es...)
+	return t
+}
+
+func (t Timeline) property(p x) (total y) {
+	if t.index == nil {
+		return 0
+	}
+	t.index.overlapping(0, len(t.index.events), p, func(e Event) {
+		total += e.value(p - e.Start)
+	})
+	return
+}
+
+// the largest MaxX of the Events, (0 if none are after zero.)
+func (t Timeline) MaxX() x {
+	if t.index == nil || len(t.index.events) == 0 {
+		return 0
+	}
+	if max := t.index.maxEnds[len(t.index.events)/2]; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// Len returns the number of Events.
+func (t Timeline) Len() int {
+	if t.index == nil {
+		return 0
+	}
+	return len(t.index.events)
+}
+
+// Events returns a copy of the Events, in Start order, (those with the same Start in the order they were added.)
+func (t Timeline) Events() []Event {
+	if t.index == nil {
+		return nil
+	}
+	return append([]Event(nil), t.index.events...)
+}
+
+// Overlapping returns the Events that are evaluated for an x, in Start order.
+func (t Timeline) Overlapping(p x) (es []Event) {
+	if t.index == nil {
+		return nil
+	}
+	t.index.overlapping(0, len(t.index.events), p, func(e Event) { es = append(es, e) })
+	return
+}
+
+// Add adds Events.
+func (t Timeline) Add(es ...Event) {
+	ix := t.index
+	ix.events = append(ix.events, es...)
+	sort.SliceStable(ix.events, func(i, j int) bool { return ix.events[i].Start < ix.events[j].Start })
+	ix.ends = make([]x, len(ix.events))
+	for i, e := range ix.events {
+		ix.ends[i] = e.MaxX()
+	}
+	ix.build()
+}
+
+// Remove removes the Events from i up to, but not including, j, in the order Events returns them.
+func (t Timeline) Remove(i, j int) {
+	ix := t.index
+	ix.events = append(ix.events[:i], ix.events[j:]...)
+	ix.ends = append(ix.ends[:i], ix.ends[j:]...)
+	ix.build()
+}
+
+func (ix *timelineIndex) build() {
+	ix.maxEnds = make([]x, len(ix.events))
+	if len(ix.events) > 0 {
+		ix.maxEnd(0, len(ix.events))
+	}
+}
+
+// sets, and returns, the largest end in a range of Events, at its middle, and so for every range within it.
+func (ix *timelineIndex) maxEnd(lo, hi int) x {
+	mid := (lo + hi) / 2
+	max := ix.ends[mid]
+	if lo < mid {
+		if m := ix.maxEnd(lo, mid); m > max {
+			max = m
+		}
+	}
+	if mid+1 < hi {
+		if m := ix.maxEnd(mid+1, hi); m > max {
+			max = m
+		}
+	}
+	ix.maxEnds[mid] = max
+	return max
+}
+
+// calls a func with the Events, in a range, that overlap an x, in order, skipping ranges that all end before it, or start after it.
+func (ix *timelineIndex) overlapping(lo, hi int, p x, fn func(Event)) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	if ix.maxEnds[mid] <= p {
+		return
+	}
+	ix.overlapping(lo, mid, p, fn)
+	if ix.events[mid].Start > p {
+		return
+	}
+	if p < ix.ends[mid] {
+		fn(ix.events[mid])
+	}
+	ix.overlapping(mid+1, hi, p, fn)
+}
+
+// GobEncode encodes the Events.
+func (t Timeline) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(t.Events())
+	return b.Bytes(), err
+}
+
+// GobDecode makes a Timeline from its Events.
+func (t *Timeline) GobDecode(data []byte) error {
+	var es []Event
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&es); err != nil {
+		return err
+	}
+	*t = NewTimeline(es...)
+	return nil
+}
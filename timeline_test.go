@@ -0,0 +1,143 @@
+package signals
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+)
+
+func ExampleTimeline() {
+	beep := Modulated{Sine{Hz(880)}, Pulse{Ms(100)}}
+	t := NewTimeline(
+		NewEvent(0, beep),
+		Event{beep, Ms(500), unitY / 2, FadeIn{Constant{unitY}, Ms(10), Linear}},
+		NewEvent(Ms(450), beep),
+	)
+	fmt.Printf("%.1fms\n", t.MaxX().Ms())
+	fmt.Println(len(t.Overlapping(Ms(520))))
+	// Output:
+	// 600.0ms
+	// 2
+}
+
+func TestTimelineEvent(t *testing.T) {
+	e := Event{Pulse{Ms(10)}, Ms(5), unitY / 2, nil}
+	half := multiplyY(unitY, unitY/2)
+	for _, c := range []struct {
+		p x
+		v y
+	}{{0, 0}, {Ms(5) - Ms(1)/10, 0}, {Ms(5), half}, {Ms(15) - Ms(1)/10, half}, {Ms(15) + Ms(1)/10, 0}} {
+		if v := e.property(c.p); v != c.v {
+			t.Error(c.p, v, c.v)
+		}
+	}
+	if e.MaxX() != Ms(15) {
+		t.Error(e.MaxX())
+	}
+	// the Envelope has x's from the Event's Start.
+	e = Event{Pulse{Ms(10)}, Ms(5), unitY, Pulse{Ms(2)}}
+	if e.property(Ms(6)) != multiplyY(unitY, unitY) || e.property(Ms(8)) != 0 {
+		t.Error(e.property(Ms(6)), e.property(Ms(8)))
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	es := []Event{
+		NewEvent(Ms(10), Pulse{Ms(30)}),
+		{Modulated{Sine{Ms(3)}, Pulse{Ms(50)}}, 0, unitY / 4, nil},
+		NewEvent(Ms(20), Pulse{Ms(5)}),
+		{Modulated{Square{Ms(7)}, Pulse{Ms(20)}}, Ms(35), unitY / 4, NewADSREnvelope(Ms(2), Ms(2), Ms(10), unitY/2, Ms(6))},
+		{Pulse{Ms(10)}, Ms(35), unitY / 3, FadeIn{Constant{unitY}, Ms(5), SCurve}},
+		NewEvent(-Ms(5), Pulse{Ms(10)}),
+		NewEvent(Ms(90), Pulse{Ms(1)}),
+	}
+	tl := NewTimeline(es...)
+	sameAsComposite(t, tl, es)
+	if tl.MaxX() != Ms(91) {
+		t.Error(tl.MaxX())
+	}
+	if o := tl.Overlapping(Ms(37)); len(o) != 4 || o[0].Start != 0 || o[1].Start != Ms(10) || o[2].Gain != unitY/4 || o[3].Gain != unitY/3 {
+		t.Error(o)
+	}
+	if o := tl.Overlapping(Ms(60)); len(o) != 0 {
+		t.Error(o)
+	}
+	tl.Add(NewEvent(Ms(15), Pulse{Ms(100)}), NewEvent(Ms(40), Pulse{Ms(3)}))
+	es = append(es, NewEvent(Ms(15), Pulse{Ms(100)}), NewEvent(Ms(40), Pulse{Ms(3)}))
+	sameAsComposite(t, tl, es)
+	if tl.MaxX() != Ms(115) || tl.Len() != len(es) {
+		t.Error(tl.MaxX(), tl.Len())
+	}
+	// Events are in Start order, those with the same Start in the order added.
+	sorted := tl.Events()
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Start < sorted[i-1].Start {
+			t.Error(sorted)
+		}
+	}
+	if sorted[5].Gain != unitY/4 || sorted[6].Gain != unitY/3 {
+		t.Error(sorted[5], sorted[6])
+	}
+	tl.Remove(2, 5)
+	sameAsComposite(t, tl, append(sorted[:2:2], sorted[5:]...))
+	tl.Remove(0, tl.Len())
+	sameAsComposite(t, tl, nil)
+	var empty Timeline
+	if empty.MaxX() != 0 || empty.property(0) != 0 || empty.Len() != 0 || empty.Overlapping(0) != nil {
+		t.Error("empty")
+	}
+}
+
+func TestTimelineGOB(t *testing.T) {
+	es := []Event{NewEvent(Ms(10), Pulse{Ms(30)}), {Modulated{Sine{Ms(3)}, Pulse{Ms(50)}}, 0, unitY / 4, RampDown{Ms(50)}}}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(&struct{ Signal }{NewTimeline(es...)}); err != nil {
+		t.Fatal(err)
+	}
+	var d struct{ Signal }
+	if err := gob.NewDecoder(&b).Decode(&d); err != nil {
+		t.Fatal(err)
+	}
+	tl, ok := d.Signal.(Timeline)
+	if !ok {
+		t.Fatalf("%T", d.Signal)
+	}
+	sameAsComposite(t, tl, es)
+}
+
+// a Timeline has the same MaxX, and values, as a Composite of its Events.
+func sameAsComposite(t *testing.T, tl Timeline, es []Event) {
+	t.Helper()
+	c := make(Composite, len(es))
+	for i := range es {
+		c[i] = es[i]
+	}
+	if tl.MaxX() != c.MaxX() || tl.Len() != len(es) {
+		t.Fatal(tl.MaxX(), c.MaxX(), tl.Len(), len(es))
+	}
+	sameSampled(t, c, tl, -Ms(10), c.MaxX()+Ms(10), Ms(1)/8, 1e-9)
+}
+
+func BenchmarkTimeline(b *testing.B) {
+	es := make([]Event, 10000)
+	for i := range es {
+		es[i] = NewEvent(x(i)*Ms(10), Modulated{Sine{Ms(1)}, Pulse{Ms(25)}})
+	}
+	t := NewTimeline(es...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t.property(x(i%100000) * Ms(1))
+	}
+}
+
+func BenchmarkTimelineComposite(b *testing.B) {
+	c := make(Composite, 10000)
+	for i := range c {
+		c[i] = NewEvent(x(i)*Ms(10), Modulated{Sine{Ms(1)}, Pulse{Ms(25)}})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.property(x(i%100000) * Ms(1))
+	}
+}
@@ -0,0 +1,318 @@
+package signals
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// the kinds of value a parameter of a Signal type can have.
+type kind uint8
+
+const (
+	signalKind         kind = iota // Signal
+	limitedSignalKind              // LimitedSignal
+	periodicSignalKind             // PeriodicSignal
+	xKind                          // x
+	periodKind                     // x, that is more naturally a frequency.
+	yKind                          // y
+	float32Kind                    // float32
+	float64Kind                    // float64
+	boolKind                       // bool
+	stringKind                     // string
+	bytesKind                      // []byte
+	bitsKind                       // big.Int
+	intKind                        // int
+	curveKind                      // Curve
+)
+
+// a parameter is the name and kind of one value needed to make a Signal.
+type parameter struct {
+	name string
+	kind kind
+}
+
+// a signalType describes how to make a Signal from parameter values.
+// variadic types take any number of values for their last parameter.
+type signalType struct {
+	parameters []parameter
+	variadic   bool
+	make       func([]interface{}) Signal
+}
+
+// the built-in Signal types, by name. describe() is the reverse, from a Signal to its name and parameter values.
+var signalTypes = map[string]signalType{
+	"Constant":     {[]parameter{{"Constant", yKind}}, false, func(a []interface{}) Signal { return Constant{a[0].(y)} }},
+	"Sine":         {[]parameter{{"Cycle", periodKind}}, false, func(a []interface{}) Signal { return Sine{a[0].(x)} }},
+	"Sinc":         {[]parameter{{"Cycle", periodKind}}, false, func(a []interface{}) Signal { return Sinc{a[0].(x)} }},
+	"Gauss":        {[]parameter{{"Q22", float64Kind}}, false, func(a []interface{}) Signal { return Gauss{a[0].(float64)} }},
+	"Pulse":        {[]parameter{{"Width", xKind}}, false, func(a []interface{}) Signal { return Pulse{a[0].(x)} }},
+	"Square":       {[]parameter{{"Cycle", periodKind}}, false, func(a []interface{}) Signal { return Square{a[0].(x)} }},
+	"RampUp":       {[]parameter{{"Period", xKind}}, false, func(a []interface{}) Signal { return RampUp{a[0].(x)} }},
+	"RampDown":     {[]parameter{{"Period", xKind}}, false, func(a []interface{}) Signal { return RampDown{a[0].(x)} }},
+	"Heavyside":    {nil, false, func(a []interface{}) Signal { return Heavyside{} }},
+	"Sigmoid":      {[]parameter{{"Steepness", xKind}}, false, func(a []interface{}) Signal { return Sigmoid{a[0].(x)} }},
+	"PulsePattern": {[]parameter{{"BitPattern", bitsKind}, {"PulseWidth", xKind}}, false, func(a []interface{}) Signal { return PulsePattern{*a[0].(*big.Int), a[1].(x)} }},
+	"ADSREnvelope": {[]parameter{{"Attack", xKind}, {"Decay", xKind}, {"Sustain", xKind}, {"SustainLevel", yKind}, {"Release", xKind}}, false, func(a []interface{}) Signal {
+		return NewADSREnvelope(a[0].(x), a[1].(x), a[2].(x), a[3].(y), a[4].(x))
+	}},
+	"Noise":         {nil, false, func(a []interface{}) Signal { return NewNoise() }},
+	"Shifted":       {[]parameter{{"Signal", signalKind}, {"Shift", xKind}}, false, func(a []interface{}) Signal { return Shifted{a[0].(Signal), a[1].(x)} }},
+	"Offset":        {[]parameter{{"LimitedSignal", limitedSignalKind}, {"Offset", xKind}}, false, func(a []interface{}) Signal { return Offset{a[0].(LimitedSignal), a[1].(x)} }},
+	"Compressed":    {[]parameter{{"Signal", signalKind}, {"Factor", float32Kind}}, false, func(a []interface{}) Signal { return Compressed{a[0].(Signal), a[1].(float32)} }},
+	"Looped":        {[]parameter{{"Signal", signalKind}, {"Loop", xKind}}, false, func(a []interface{}) Signal { return Looped{a[0].(Signal), a[1].(x)} }},
+	"Repeated":      {[]parameter{{"PeriodicSignal", periodicSignalKind}, {"Cycles", float32Kind}}, false, func(a []interface{}) Signal { return Repeated{a[0].(PeriodicSignal), a[1].(float32)} }},
+	"Inverted":      {[]parameter{{"Signal", signalKind}}, false, func(a []interface{}) Signal { return Inverted{a[0].(Signal)} }},
+	"Reversed":      {[]parameter{{"Signal", signalKind}}, false, func(a []interface{}) Signal { return Reversed{a[0].(Signal)} }},
+	"Reflected":     {[]parameter{{"Signal", signalKind}}, false, func(a []interface{}) Signal { return Reflected{a[0].(Signal)} }},
+	"Interpolated":  {[]parameter{{"PeriodicSignal", periodicSignalKind}}, false, func(a []interface{}) Signal { return Interpolated{a[0].(PeriodicSignal)} }},
+	"RateModulated": {[]parameter{{"Signal", signalKind}, {"Modulation", signalKind}, {"Factor", xKind}}, false, func(a []interface{}) Signal { return RateModulated{a[0].(Signal), a[1].(Signal), a[2].(x)} }},
+	"Segmented":     {[]parameter{{"Signal", signalKind}, {"Width", xKind}}, false, func(a []interface{}) Signal { return NewSegmented(a[0].(Signal), a[1].(x)) }},
+	"Triggered": {[]parameter{{"Signal", signalKind}, {"Trigger", yKind}, {"Rising", boolKind}, {"Resolution", xKind}, {"MaxShift", xKind}}, false, func(a []interface{}) Signal {
+		return NewTriggered(a[0].(Signal), a[1].(y), a[2].(bool), a[3].(x), a[4].(x))
+	}},
+	"Cached":   {[]parameter{{"Signal", signalKind}, {"Size", intKind}, {"Quantum", xKind}}, false, func(a []interface{}) Signal { return NewCached(a[0].(Signal), a[1].(int), a[2].(x)) }},
+	"Buffered": {[]parameter{{"Signal", signalKind}, {"Period", periodKind}, {"Block", intKind}, {"Ahead", intKind}}, false, func(a []interface{}) Signal { return NewBuffered(a[0].(Signal), a[1].(x), a[2].(int), a[3].(int)) }},
+	"FadeIn":   {[]parameter{{"Signal", signalKind}, {"Width", xKind}, {"Curve", curveKind}}, false, func(a []interface{}) Signal { return FadeIn{a[0].(Signal), a[1].(x), a[2].(Curve)} }},
+	"FadeOut":  {[]parameter{{"LimitedSignal", limitedSignalKind}, {"Width", xKind}, {"Curve", curveKind}}, false, func(a []interface{}) Signal { return FadeOut{a[0].(LimitedSignal), a[1].(x), a[2].(Curve)} }},
+	"CrossfadeSequence": {[]parameter{{"Overlap", xKind}, {"Curve", curveKind}, {"LimitedSignals", limitedSignalKind}}, true, func(a []interface{}) Signal {
+		return NewCrossfadeSequence(a[0].(x), a[1].(Curve), limitedSignals(a[2:])...)
+	}},
+	"Modulated":       {[]parameter{{"Signals", signalKind}}, true, func(a []interface{}) Signal { return Modulated(signals(a)) }},
+	"Composite":       {[]parameter{{"Signals", signalKind}}, true, func(a []interface{}) Signal { return Composite(signals(a)) }},
+	"Stacked":         {[]parameter{{"Signals", signalKind}}, true, func(a []interface{}) Signal { return Stacked(signals(a)) }},
+	"Sequenced":       {[]parameter{{"LimitedSignals", limitedSignalKind}}, true, func(a []interface{}) Signal { return Sequenced(limitedSignals(a)) }},
+	"IndexedSequence": {[]parameter{{"LimitedSignals", limitedSignalKind}}, true, func(a []interface{}) Signal { return NewIndexedSequence(limitedSignals(a)...) }},
+	"Event": {[]parameter{{"LimitedSignal", limitedSignalKind}, {"Start", xKind}, {"Gain", yKind}, {"Envelope", signalKind}}, false, func(a []interface{}) Signal {
+		return Event{a[0].(LimitedSignal), a[1].(x), a[2].(y), envelope(a[3].(Signal))}
+	}},
+	"Timeline": {[]parameter{{"Events", limitedSignalKind}}, true, func(a []interface{}) Signal { return NewTimeline(events(a)...) }},
+	"PCM8bit":  {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM8bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"PCM16bit": {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM16bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"PCM24bit": {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM24bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"PCM32bit": {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM32bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"PCM48bit": {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM48bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"PCM64bit": {[]parameter{{"Period", periodKind}, {"Data", bytesKind}}, false, func(a []interface{}) Signal { return PCM64bit{PCM{a[0].(x), a[1].([]byte)}} }},
+	"Wave":     {[]parameter{{"URL", stringKind}}, false, func(a []interface{}) Signal { return &Wave{URL: a[0].(string)} }},
+}
+
+// Events from a slice of LimitedSignal interface{}'s, those that aren't Events are put at zero.
+func events(a []interface{}) []Event {
+	es := make([]Event, len(a))
+	for i := range a {
+		if e, ok := a[i].(Event); ok {
+			es[i] = e
+		} else {
+			es[i] = NewEvent(0, a[i].(LimitedSignal))
+		}
+	}
+	return es
+}
+
+// an Event's Envelope, a unit Constant, (how no Envelope is described), is none.
+func envelope(s Signal) Signal {
+	if c, ok := s.(Constant); ok && c.Constant == unitY {
+		return nil
+	}
+	return s
+}
+
+// Signals from a slice of Signal interface{}'s.
+func signals(a []interface{}) []Signal {
+	ss := make([]Signal, len(a))
+	for i := range a {
+		ss[i] = a[i].(Signal)
+	}
+	return ss
+}
+
+// LimitedSignals from a slice of LimitedSignal interface{}'s.
+func limitedSignals(a []interface{}) []LimitedSignal {
+	ss := make([]LimitedSignal, len(a))
+	for i := range a {
+		ss[i] = a[i].(LimitedSignal)
+	}
+	return ss
+}
+
+// interface{}'s from a slice of Signals.
+func signalValues(ss []Signal) []interface{} {
+	a := make([]interface{}, len(ss))
+	for i := range ss {
+		a[i] = ss[i]
+	}
+	return a
+}
+
+// describe returns the name of a built-in Signal's type and the values of its parameters, as needed by its signalType to make it again.
+func describe(s Signal) (name string, values []interface{}, err error) {
+	switch st := s.(type) {
+	case Constant:
+		return "Constant", []interface{}{st.Constant}, nil
+	case Sine:
+		return "Sine", []interface{}{st.Cycle}, nil
+	case Sinc:
+		return "Sinc", []interface{}{st.Cycle}, nil
+	case Gauss:
+		return "Gauss", []interface{}{st.Q22}, nil
+	case Pulse:
+		return "Pulse", []interface{}{st.Width}, nil
+	case Square:
+		return "Square", []interface{}{st.Cycle}, nil
+	case RampUp:
+		return "RampUp", []interface{}{st.Period}, nil
+	case RampDown:
+		return "RampDown", []interface{}{st.Period}, nil
+	case Heavyside:
+		return "Heavyside", nil, nil
+	case Sigmoid:
+		return "Sigmoid", []interface{}{st.Steepness}, nil
+	case PulsePattern:
+		return "PulsePattern", []interface{}{&st.BitPattern, st.PulseWidth}, nil
+	case ADSREnvelope:
+		return "ADSREnvelope", []interface{}{st.attackEnd, st.sustainStart - st.attackEnd, st.sustainEnd - st.sustainStart, st.sustain, st.end - st.sustainEnd}, nil
+	case Noise:
+		return "Noise", nil, nil
+	case Shifted:
+		return "Shifted", []interface{}{st.Signal, st.Shift}, nil
+	case Offset:
+		return "Offset", []interface{}{st.LimitedSignal, st.Offset}, nil
+	case Compressed:
+		return "Compressed", []interface{}{st.Signal, st.Factor}, nil
+	case Looped:
+		return "Looped", []interface{}{st.Signal, st.Loop}, nil
+	case Repeated:
+		return "Repeated", []interface{}{st.PeriodicSignal, st.Cycles}, nil
+	case Inverted:
+		return "Inverted", []interface{}{st.Signal}, nil
+	case Reversed:
+		return "Reversed", []interface{}{st.Signal}, nil
+	case Reflected:
+		return "Reflected", []interface{}{st.Signal}, nil
+	case Interpolated:
+		return "Interpolated", []interface{}{st.PeriodicSignal}, nil
+	case RateModulated:
+		return "RateModulated", []interface{}{st.Signal, st.Modulation, st.Factor}, nil
+	case Segmented:
+		return "Segmented", []interface{}{st.Signal, st.Width}, nil
+	case Triggered:
+		return "Triggered", []interface{}{st.Signal, st.Trigger, st.Rising, st.Resolution, st.MaxShift}, nil
+	case Cached:
+		return "Cached", []interface{}{st.Signal, st.Size, st.Quantum}, nil
+	case Buffered:
+		return "Buffered", []interface{}{st.Signal, st.Period, st.Block, st.Ahead}, nil
+	case FadeIn:
+		return "FadeIn", []interface{}{st.Signal, st.Width, st.Curve}, curveKind.check(st.Curve)
+	case FadeOut:
+		return "FadeOut", []interface{}{st.LimitedSignal, st.Width, st.Curve}, curveKind.check(st.Curve)
+	case CrossfadeSequence:
+		return "CrossfadeSequence", append([]interface{}{st.Overlap, st.Curve}, signalValues(PromoteToSignals(st.LimitedSignals))...), curveKind.check(st.Curve)
+	case Modulated:
+		return "Modulated", signalValues(st), nil
+	case Composite:
+		return "Composite", signalValues(st), nil
+	case Stacked:
+		return "Stacked", signalValues(st), nil
+	case Sequenced:
+		return "Sequenced", signalValues(PromoteToSignals([]LimitedSignal(st))), nil
+	case IndexedSequence:
+		return "IndexedSequence", signalValues(PromoteToSignals(st.LimitedSignals())), nil
+	case Event:
+		if st.Envelope == nil {
+			st.Envelope = Constant{unitY}
+		}
+		return "Event", []interface{}{st.LimitedSignal, st.Start, st.Gain, st.Envelope}, nil
+	case Timeline:
+		es := st.Events()
+		vs := make([]interface{}, len(es))
+		for i := range es {
+			vs[i] = es[i]
+		}
+		return "Timeline", vs, nil
+	case PCM8bit:
+		return "PCM8bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case PCM16bit:
+		return "PCM16bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case PCM24bit:
+		return "PCM24bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case PCM32bit:
+		return "PCM32bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case PCM48bit:
+		return "PCM48bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case PCM64bit:
+		return "PCM64bit", []interface{}{st.samplePeriod, st.Data}, nil
+	case *Wave:
+		return "Wave", []interface{}{st.URL}, nil
+	}
+	return "", nil, errors.New(fmt.Sprintf("%T is not a built-in Signal type.", s))
+}
+
+// makeSignal makes a Signal from its type name and parameter values, checking their kinds.
+func makeSignal(name string, values []interface{}) (Signal, error) {
+	st, ok := signalTypes[name]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("unknown Signal type %q.", name))
+	}
+	if !st.variadic && len(values) != len(st.parameters) || st.variadic && len(values) < len(st.parameters)-1 {
+		return nil, errors.New(fmt.Sprintf("%s needs %d parameters, has %d.", name, len(st.parameters), len(values)))
+	}
+	for i, v := range values {
+		p := st.parameter(i)
+		if err := p.kind.check(v); err != nil {
+			return nil, errors.New(fmt.Sprintf("%s %s: %s", name, p.name, err.Error()))
+		}
+	}
+	return st.make(values), nil
+}
+
+// the parameter for the i'th value, the last parameter of a variadic type applies to all the remaining values.
+func (st signalType) parameter(i int) parameter {
+	if i >= len(st.parameters) {
+		return st.parameters[len(st.parameters)-1]
+	}
+	return st.parameters[i]
+}
+
+// check a value is of the Go type for a kind.
+func (k kind) check(v interface{}) error {
+	ok := false
+	switch k {
+	case signalKind:
+		_, ok = v.(Signal)
+	case limitedSignalKind:
+		_, ok = v.(LimitedSignal)
+	case periodicSignalKind:
+		_, ok = v.(PeriodicSignal)
+	case xKind, periodKind:
+		_, ok = v.(x)
+	case yKind:
+		_, ok = v.(y)
+	case float32Kind:
+		_, ok = v.(float32)
+	case float64Kind:
+		_, ok = v.(float64)
+	case boolKind:
+		_, ok = v.(bool)
+	case stringKind:
+		_, ok = v.(string)
+	case bytesKind:
+		_, ok = v.([]byte)
+	case bitsKind:
+		_, ok = v.(*big.Int)
+	case intKind:
+		_, ok = v.(int)
+	case curveKind:
+		var c Curve
+		if c, ok = v.(Curve); ok && c > SCurve {
+			return errors.New(fmt.Sprintf("Unsupported Curve (%d).", c))
+		}
+	}
+	if !ok {
+		return errors.New(fmt.Sprintf("%T is not a %s.", v, k))
+	}
+	return nil
+}
+
+func (k kind) String() string {
+	return [...]string{"Signal", "LimitedSignal", "PeriodicSignal", "x", "x", "y", "float32", "float64", "bool", "string", "[]byte", "big.Int", "int", "Curve"}[k]
+}
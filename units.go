@@ -0,0 +1,154 @@
+package signals
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// physical units, x's are times, or the period of a frequency, y's are levels, relative to full scale, unitY.
+// values are the nearest, (unlike X and Y, near full scale and for negatives) x's are zero if out of range, y's are clipped.
+// for example; Sine{Hz(440)}, Pulse{Ms(70)}, Constant{DBFS(-6)}, and s.Period().Hz(), s.MaxX().Time()
+
+// Seconds returns the x of a time in seconds.
+func Seconds(t float64) x {
+	return floatX(t)
+}
+
+// Ms returns the x of a time in milliseconds.
+func Ms(t float64) x {
+	return floatX(t / 1e3)
+}
+
+// Hz returns the x of one cycle of a frequency, its period.
+func Hz(f float64) x {
+	return floatX(1 / f)
+}
+
+// KHz returns the x of one cycle of a frequency in kilohertz.
+func KHz(f float64) x {
+	return floatX(1 / (f * 1e3))
+}
+
+// DBFS returns the y of a level in decibels relative to full scale, (see DB for the scale.)
+// levels above zero dB are clipped, to the same y as zero dB.
+func DBFS(db float64) y {
+	v := Vol(db)
+	switch {
+	case v > 1:
+		v = 1
+	case math.IsNaN(v):
+		return 0
+	}
+	return y(unitYfloat64 * v)
+}
+
+// Percent returns the y of a percentage of full scale.
+func Percent(p float64) y {
+	return floatY(p / 100)
+}
+
+// Volts returns the y of a voltage, with full scale being fullScale volts.
+func Volts(v, fullScale float64) y {
+	return floatY(v / fullScale)
+}
+
+// Seconds returns an x as a time in seconds.
+func (p x) Seconds() float64 {
+	return float64(p) / float64(unitX)
+}
+
+// Ms returns an x as a time in milliseconds.
+func (p x) Ms() float64 {
+	return p.Seconds() * 1e3
+}
+
+// Hz returns the frequency of an x as a period, zero for none.
+func (p x) Hz() float64 {
+	if p <= 0 {
+		return 0
+	}
+	return 1 / p.Seconds()
+}
+
+// Time returns an x as text of a time, in s, ms, µs or ns, whichever keeps the number at least one, as ParseSignal reads.
+func (p x) Time() string {
+	r := ratX(p)
+	a := new(big.Rat).Abs(r)
+	for _, u := range []struct {
+		name  string
+		scale int64
+	}{{"s", 1}, {"ms", 1e3}, {"µs", 1e6}} {
+		if a.Cmp(big.NewRat(1, u.scale)) >= 0 {
+			return decimal(r.Mul(r, big.NewRat(u.scale, 1))) + u.name
+		}
+	}
+	if r.Sign() == 0 {
+		return "0s"
+	}
+	return decimal(r.Mul(r, big.NewRat(1e9, 1))) + "ns"
+}
+
+// Frequency returns an x as text of the frequency it's the period of, in Hz, as ParseSignal reads, the roundest if several have this period.
+// rounded to 6 significant digits, if none exactly do. (so not reading back the same.)
+func (p x) Frequency() string {
+	if p <= 0 {
+		return "0Hz"
+	}
+	if s := formatPeriod(p); strings.HasSuffix(s, "Hz") {
+		return s
+	}
+	return strconv.FormatFloat(p.Hz(), 'g', 6, 64) + "Hz"
+}
+
+// DBFS returns a y as a level in decibels relative to full scale, minus infinity for zero or less.
+func (v y) DBFS() float64 {
+	if v <= 0 {
+		return math.Inf(-1)
+	}
+	return DB(float64(v) / unitYfloat64)
+}
+
+// Percent returns a y as a percentage of full scale.
+func (v y) Percent() float64 {
+	return 100 * float64(v) / float64(unitY)
+}
+
+// Volts returns a y as a voltage, with full scale being fullScale volts.
+func (v y) Volts(fullScale float64) float64 {
+	return float64(v) / float64(unitY) * fullScale
+}
+
+// shortest exact decimal, of up to 9 places, otherwise shortest float64.
+func decimal(r *big.Rat) string {
+	if r.IsInt() || new(big.Int).Mod(big.NewInt(1e9), r.Denom()).Sign() == 0 {
+		return strings.TrimSuffix(strings.TrimRight(r.FloatString(9), "0"), ".")
+	}
+	f, _ := r.Float64()
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// nearest x to a float multiple of unitX, zero if out of range.
+func floatX(f float64) x {
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return 0
+	}
+	p, _ := xRat(r)
+	return p
+}
+
+// nearest y to a float multiple of unitY, clipped to full scale.
+func floatY(f float64) y {
+	switch {
+	case f >= 1:
+		return unitY
+	case f <= -1:
+		return -unitY
+	case math.IsNaN(f):
+		return 0
+	}
+	v, _ := yRat(new(big.Rat).SetFloat64(f))
+	return v
+}
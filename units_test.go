@@ -0,0 +1,75 @@
+package signals
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func ExampleHz() {
+	s := Modulated{Sine{Hz(440)}, Pulse{Ms(250)}, Constant{DBFS(-6)}}
+	fmt.Println(s[0].(Sine).Period().Frequency(), s.MaxX().Time(), s[2].(Constant).Constant.DBFS())
+	// Output:
+	// 440Hz 250ms -6
+}
+
+func TestUnitsX(t *testing.T) {
+	if Hz(400) != unitX/400 || KHz(1) != unitX/1000 || Ms(70) != unitX*7/100 || Seconds(2) != unitX*2 {
+		t.Error(Hz(400), KHz(1), Ms(70), Seconds(2))
+	}
+	for p, text := range map[x]string{
+		Seconds(1.5): "1.5s",
+		Ms(70):       "70ms",
+		Ms(-0.5):     "-500µs",
+		unitX / 1e9:  "1ns",
+		0:            "0s",
+	} {
+		if s := p.Time(); s != text {
+			t.Error(s, text)
+		}
+		if v, err := parseValue(text, xKind); err != nil || v != p {
+			t.Error(text, v, err)
+		}
+	}
+	if f := Hz(941).Frequency(); f != "941Hz" {
+		t.Error(f)
+	}
+	if h := Hz(400).Hz(); math.Abs(h-400) > 1e-6 {
+		t.Error(h)
+	}
+	if ms := Seconds(0.25).Ms(); ms != 250 {
+		t.Error(ms)
+	}
+}
+
+func TestUnitsY(t *testing.T) {
+	if DBFS(-6) != NewConstant(-6).Constant || Percent(100) != unitY || Percent(-50) != -unitY/2 || Volts(2.5, 5) != unitY/2 {
+		t.Error(DBFS(-6), Percent(100), Percent(-50), Volts(2.5, 5))
+	}
+	if db := DBFS(-12).DBFS(); math.Abs(db+12) > 1e-9 {
+		t.Error(db)
+	}
+	for _, db := range []float64{0.1, 6, 1e3, math.Inf(1)} {
+		if v := DBFS(db); v != DBFS(0) {
+			t.Error(db, v)
+		}
+	}
+	if DBFS(math.Inf(-1)) != 0 || DBFS(math.NaN()) != 0 {
+		t.Error(DBFS(math.Inf(-1)), DBFS(math.NaN()))
+	}
+	if !math.IsInf(y(0).DBFS(), -1) {
+		t.Error(y(0).DBFS())
+	}
+	if p := Percent(25).Percent(); math.Abs(p-25) > 1e-9 {
+		t.Error(p)
+	}
+	if v := (unitY / 2).Volts(3.3); math.Abs(v-1.65) > 1e-9 {
+		t.Error(v)
+	}
+}
+
+func TestUnitsRange(t *testing.T) {
+	if Volts(12, 5) != unitY || Volts(-12, 5) != -unitY || Hz(0) != 0 {
+		t.Error(Volts(12, 5), Volts(-12, 5), Hz(0))
+	}
+}
@@ -29,9 +29,9 @@ func MultiplyY(m interface{}, d y) y {
 	case uint64:
 		return d * y(mt)
 	case float32:
-		return y(float32(d)*mt + .5)
+		return scaleY32(d, mt)
 	case float64:
-		return y(float64(d)*mt + .5)
+		return scaleY64(d, mt)
 	default:
 		return d
 	}
@@ -66,9 +66,9 @@ func MultiplyX(m interface{}, d x) x {
 	case uint64:
 		return d * x(mt)
 	case float32:
-		return x(float32(d)*mt + .5)
+		return scaleX32(d, mt)
 	case float64:
-		return x(float64(d)*mt + .5)
+		return scaleX64(d, mt)
 	default:
 		return d
 	}
@@ -0,0 +1,81 @@
+package signals
+
+import "fmt"
+
+// Node describes a Signal, as one part of a composition, with its type, parameters and the Signals it's made from, its children.
+type Node struct {
+	Signal     Signal
+	Type       string      // name, as used in the text form, or, for Signals not of this package's types, the Go type.
+	Parameters []Parameter // values, other than Signals, that make it, in order.
+	Children   []Signal    // Signals that make it, in order.
+	MaxX       x           // zero if not a LimitedSignal, (or not yet known, for a Wave not yet read.)
+	Period     x           // zero if aperiodic.
+	Depth      int         // number of Signals it's in, from the one walked.
+}
+
+// Parameter is a named value of a Node.
+// Values are x, y, int, float32, float64, bool, string, []byte or *big.Int, x's and y's have methods for their physical units.
+type Parameter struct {
+	Name  string
+	Value interface{}
+	Text  string // as in the text form, so seconds, a frequency, a %, or dB.
+}
+
+// Describe returns the Node of a Signal, (with a Depth of zero.)
+func Describe(s Signal) *Node {
+	n := &Node{Signal: s, MaxX: limit(s), Period: period(s)}
+	name, values, err := describe(s)
+	if err != nil {
+		n.Type = fmt.Sprintf("%T", s)
+		return n
+	}
+	n.Type = name
+	st := signalTypes[name]
+	for i, v := range values {
+		p := st.parameter(i)
+		if p.kind.isSignal() {
+			n.Children = append(n.Children, v.(Signal))
+			continue
+		}
+		n.Parameters = append(n.Parameters, Parameter{p.name, v, formatValue(v, p.kind)})
+	}
+	return n
+}
+
+// A Visitor's Visit method is called with each Node Walk finds.
+// If the Visitor returned isn't nil, Walk visits the Node's children with it, and then calls its Visit with nil.
+type Visitor interface {
+	Visit(n *Node) (w Visitor)
+}
+
+// Walk traverses a composition, depth first, calling v.Visit(n) with the Node of s, and, unless that returns nil, Walks its children, with the Visitor returned, then calls its Visit(nil).
+// (the same as go/ast's Walk.)
+func Walk(v Visitor, s Signal) {
+	walk(v, s, 0)
+}
+
+func walk(v Visitor, s Signal, depth int) {
+	n := Describe(s)
+	n.Depth = depth
+	if v = v.Visit(n); v == nil {
+		return
+	}
+	for _, c := range n.Children {
+		walk(v, c, depth+1)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(*Node) bool
+
+func (f inspector) Visit(n *Node) Visitor {
+	if f(n) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses a composition, depth first, calling f with each Node, and, when f returns true, with its children, followed by f(nil).
+func Inspect(s Signal, f func(*Node) bool) {
+	Walk(inspector(f), s)
+}
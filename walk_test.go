@@ -0,0 +1,104 @@
+package signals
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func ExampleInspect() {
+	busy := Modulated{Looped{Pulse{Ms(500)}, Ms(1000)}, Stacked{Sine{Hz(480)}, Sine{Hz(620)}}, NewConstant(-6)}
+	Inspect(busy, func(n *Node) bool {
+		if n != nil {
+			fmt.Print(strings.Repeat("  ", n.Depth), n.Type)
+			for _, p := range n.Parameters {
+				fmt.Print(" ", p.Name, "=", p.Text)
+			}
+			fmt.Println(" MaxX", n.MaxX.Time(), "Period", n.Period.Frequency())
+		}
+		return true
+	})
+	// Output:
+	// Modulated MaxX 0s Period 1Hz
+	//   Looped Loop=1 MaxX 0s Period 1Hz
+	//     Pulse Width=0.5 MaxX 500ms Period 0Hz
+	//   Stacked MaxX 0s Period 20Hz
+	//     Sine Cycle=480Hz MaxX 0s Period 480Hz
+	//     Sine Cycle=620Hz MaxX 0s Period 620Hz
+	//   Constant Constant=-6dB MaxX 0s Period 0Hz
+}
+
+// a Signal that isn't one of the package's types.
+type otherSignal struct{}
+
+func (otherSignal) property(x) y { return 0 }
+
+// counts Nodes, and the Visit(nil)'s, that end the children of a Node.
+type countingVisitor struct {
+	nodes, ends *int
+	prune       string
+}
+
+func (v countingVisitor) Visit(n *Node) Visitor {
+	if n == nil {
+		*v.ends++
+		return nil
+	}
+	*v.nodes++
+	if n.Type == v.prune {
+		return nil
+	}
+	return v
+}
+
+func TestWalk(t *testing.T) {
+	s := Composite{Shifted{Modulated{Sine{Ms(1)}, otherSignal{}}, Ms(1)}, Sequenced{Pulse{Ms(1)}, Offset{Pulse{Ms(2)}, Ms(3)}}}
+	var nodes, ends int
+	Walk(countingVisitor{&nodes, &ends, ""}, s)
+	if nodes != 9 || ends != 9 {
+		t.Error(nodes, ends)
+	}
+	nodes, ends = 0, 0
+	Walk(countingVisitor{&nodes, &ends, "Shifted"}, s)
+	if nodes != 6 || ends != 5 {
+		t.Error(nodes, ends)
+	}
+	n := Describe(s[0].(Shifted).Signal.(Modulated)[1])
+	if n.Type != "signals.otherSignal" || len(n.Children) != 0 || len(n.Parameters) != 0 {
+		t.Errorf("%+v", n)
+	}
+	n = Describe(s[1])
+	if n.Type != "Sequenced" || len(n.Children) != 2 || n.MaxX != Ms(6) || n.Period != 0 {
+		t.Errorf("%+v", n)
+	}
+	n = Describe(NewCached(Sine{Ms(1)}, 100, Ms(1)/10))
+	if len(n.Parameters) != 2 || n.Parameters[0].Name != "Size" || n.Parameters[0].Value != 100 || n.Parameters[1].Text != "0.0001" {
+		t.Errorf("%+v", n)
+	}
+	n = Describe(&Wave{URL: "file:///not/read.wav"})
+	if n.Type != "Wave" || n.MaxX != 0 || n.Parameters[0].Value != "file:///not/read.wav" {
+		t.Errorf("%+v", n)
+	}
+}
+
+func TestWalkWaveNotRead(t *testing.T) {
+	w := &Wave{URL: "file:///not/read.wav"}
+	s := Stacked{Modulated{Sine{Ms(1)}, w}, Offset{w, Ms(1)}, Repeated{Stacked{Sine{Ms(2)}, w}, 2}}
+	var nodes, ends int
+	Walk(countingVisitor{&nodes, &ends, ""}, s)
+	if nodes != 10 || ends != 10 {
+		t.Error(nodes, ends)
+	}
+	if err := WriteDOT(ioutil.Discard, s); err != nil {
+		t.Error(err)
+	}
+	if err := WriteMermaid(ioutil.Discard, s); err != nil {
+		t.Error(err)
+	}
+	Optimize(s)
+	NewProfile(s)
+	if w.loaded() {
+		t.Error("read.")
+	}
+}